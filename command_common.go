@@ -1,5 +1,40 @@
 package main
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evalphobia/go-jp-text-ripper/prefilter"
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// markup types for --markup option.
+const (
+	markupHTML     = "html"
+	markupMarkdown = "markdown"
+	markupAozora   = "aozora"
+)
+
+// modes for --itaiji option.
+const (
+	itaijiText = "text"
+	itaijiKey  = "key"
+)
+
+// modes for --number option.
+const (
+	numberValue = "value"
+	numberMask  = "mask"
+)
+
+// types for --fold option.
+const (
+	foldKana    = "kana"
+	foldCase    = "case"
+	foldWidth   = "width"
+	foldReading = "reading"
+)
+
 // CommonOption of sub commands.
 type CommonOption struct {
 	Column           string `cli:"c,column" usage:"target column name in input file"`
@@ -14,6 +49,186 @@ type CommonOption struct {
 	UseVerb          bool   `cli:"verb" usage:"output 'verb' type of word"`
 	UseAdjective     bool   `cli:"adjective" usage:"output 'adjective' type of word"`
 	UseNeologd       bool   `cli:"neologd" usage:"use prefilter for neologd"`
+	UseNFKC          bool   `cli:"nfkc" usage:"use prefilter for unicode NFKC normalization"`
+	Number           string `cli:"number" usage:"convert number expressions (e.g. '三千五百', '3.5千') into arabic numerals, or replace the number words with '<NUM>' (value, mask)"`
+	Squash           int    `cli:"squash" usage:"collapse runs of the same character beyond N, and laughter (w, 笑, 草) into one"`
+	Laughter         string `cli:"laugh" usage:"replace laughter (w, 笑, 草) with the token --laugh='<LAUGH>' (used with --squash)"`
+	Markup           string `cli:"markup" usage:"remove markup from text (html, markdown, aozora)"`
+	UseProtectEntity bool   `cli:"protect-entity" usage:"replace url, email, hashtag and mention with the placeholders to protect them from tokenization"`
+	UseEmojiName     bool   `cli:"emoji-name" usage:"replace emoji with the word of CLDR short name (e.g. '😂' -> 'FaceWithTearsOfJoy')"`
+	EmojiDic         string `cli:"emoji-dic" usage:"emoji name table file path to add into the bundled table"`
+	Itaiji           string `cli:"itaiji" usage:"normalize kanji variants in the text, or only in the key for matching and ranking (text, key)"`
+	ItaijiDic        string `cli:"itaiji-dic" usage:"kanji variant table file path to add into the bundled table"`
+	Fold             string `cli:"fold" usage:"unify the word key for matching and ranking (kana, case, width, reading) separated by comma"`
 	ProgressInterval int    `cli:"progress" usage:"print current progress (sec)" dft:"30"`
 	MinLetterSize    int    `cli:"min" usage:"minimum letter size for output" dft:"1"`
 }
+
+// setFilters sets prefilters and key filters from the options into the config.
+// markup, entity and emoji prefilters run before the other normalizers, NFKC runs before neologd,
+// and number and squash run after them.
+// maskFilters (e.g. PII) run just after the markup removal, before the other prefilters rewrite the text.
+func (o CommonOption) setFilters(c *ripper.CommonConfig, maskFilters ...*ripper.PreFilter) error {
+	markup, err := o.markupFilters()
+	if err != nil {
+		return err
+	}
+	c.PreFilters = append(c.PreFilters, markup...)
+	c.PreFilters = append(c.PreFilters, maskFilters...)
+
+	if o.UseProtectEntity {
+		c.PreFilters = append(c.PreFilters, prefilter.Entity)
+	}
+	if o.UseEmojiName || o.EmojiDic != "" {
+		f, err := prefilter.NewEmojiNamePreFilter(o.EmojiDic)
+		if err != nil {
+			return err
+		}
+		c.PreFilters = append(c.PreFilters, f)
+	}
+	if c.UseNFKC {
+		c.PreFilters = append(c.PreFilters, prefilter.NFKC)
+	}
+	if c.UseNeologd {
+		c.PreFilters = append(c.PreFilters, prefilter.Neologd)
+	}
+	switch o.Number {
+	case "":
+	case numberValue:
+		c.PreFilters = append(c.PreFilters, prefilter.Number)
+	case numberMask:
+		// join the number expressions into arabic numerals, and replace the number words after tokenization
+		c.PreFilters = append(c.PreFilters, prefilter.Number)
+		c.NumberMask = prefilter.MaskNumber
+	default:
+		return fmt.Errorf("invalid number mode: [%s]\nSet -number <value|mask>", o.Number)
+	}
+	if o.Squash > 0 || o.Laughter != "" {
+		c.PreFilters = append(c.PreFilters, prefilter.NewSquashPreFilter(prefilter.SquashConfig{
+			MaxRepeat:     o.Squash,
+			LaughterToken: o.Laughter,
+		}))
+	}
+
+	switch {
+	case o.Itaiji == "" && o.ItaijiDic == "":
+	case o.Itaiji == "" || o.Itaiji == itaijiText:
+		f, err := prefilter.NewItaijiPreFilter(o.ItaijiDic)
+		if err != nil {
+			return err
+		}
+		c.PreFilters = append(c.PreFilters, f)
+	case o.Itaiji == itaijiKey:
+		f, err := prefilter.NewItaijiPreFilter(o.ItaijiDic)
+		if err != nil {
+			return err
+		}
+		c.KeyFilters = append(c.KeyFilters, f)
+	default:
+		return fmt.Errorf("invalid itaiji mode: [%s]\nSet -itaiji <text|key>", o.Itaiji)
+	}
+
+	if o.Fold == "" {
+		return nil
+	}
+	for _, f := range strings.Split(o.Fold, ",") {
+		switch strings.TrimSpace(f) {
+		case foldKana:
+			c.KeyFilters = append(c.KeyFilters, prefilter.KanaFold)
+		case foldCase:
+			c.KeyFilters = append(c.KeyFilters, prefilter.CaseFold)
+		case foldWidth:
+			c.KeyFilters = append(c.KeyFilters, prefilter.WidthFold)
+		case foldReading:
+			c.UseReadingKey = true
+		default:
+			return fmt.Errorf("invalid fold type: [%s]\nSet -fold <kana,case,width,reading>", f)
+		}
+	}
+	return nil
+}
+
+// markupFilters returns the prefilters to remove markup.
+func (o CommonOption) markupFilters() ([]*ripper.PreFilter, error) {
+	switch o.Markup {
+	case "":
+		return nil, nil
+	case markupHTML:
+		return []*ripper.PreFilter{prefilter.HTML}, nil
+	case markupMarkdown:
+		return []*ripper.PreFilter{prefilter.Markdown}, nil
+	case markupAozora:
+		return []*ripper.PreFilter{prefilter.Aozora}, nil
+	default:
+		return nil, fmt.Errorf("invalid markup: [%s]\nSet -markup <html|markdown|aozora>", o.Markup)
+	}
+}
+
+// filterSetting returns the filter options to save in the model.
+func (o CommonOption) filterSetting() ripper.FilterSetting {
+	return ripper.FilterSetting{
+		Markup:           o.Markup,
+		UseProtectEntity: o.UseProtectEntity,
+		UseEmojiName:     o.UseEmojiName,
+		EmojiDic:         o.EmojiDic,
+		Number:           o.Number,
+		Squash:           o.Squash,
+		Laughter:         o.Laughter,
+		Itaiji:           o.Itaiji,
+		ItaijiDic:        o.ItaijiDic,
+		Fold:             o.Fold,
+	}
+}
+
+// applyModelSetting sets the tokenizer and filter options saved in the model.
+// it returns error when the option is set and conflicts with the model.
+func (o *CommonOption) applyModelSetting(s ripper.TokenizerSetting) error {
+	f := s.Filter
+	conflicts := []struct {
+		name       string
+		isSet      bool
+		isConflict bool
+	}{
+		// the model keeps the stop words, not the file path
+		{"stopword", o.StopWord != "", true},
+		{"original", o.UseOriginalForm, o.UseOriginalForm != s.UseOriginalForm},
+		{"noun", o.UseNoun, o.UseNoun != s.UseNoun},
+		{"verb", o.UseVerb, o.UseVerb != s.UseVerb},
+		{"adjective", o.UseAdjective, o.UseAdjective != s.UseAdjective},
+		// the default value is treated as not set
+		{"min", o.MinLetterSize != 1, o.MinLetterSize != s.MinLetterSize},
+		{"dic", o.Dictionary != "", o.Dictionary != s.Dictionary},
+		{"neologd", o.UseNeologd, o.UseNeologd != s.UseNeologd},
+		{"nfkc", o.UseNFKC, o.UseNFKC != s.UseNFKC},
+		{"markup", o.Markup != "", o.Markup != f.Markup},
+		{"protect-entity", o.UseProtectEntity, o.UseProtectEntity != f.UseProtectEntity},
+		{"emoji-name", o.UseEmojiName, o.UseEmojiName != f.UseEmojiName},
+		{"emoji-dic", o.EmojiDic != "", o.EmojiDic != f.EmojiDic},
+		{"number", o.Number != "", o.Number != f.Number},
+		{"squash", o.Squash != 0, o.Squash != f.Squash},
+		{"laugh", o.Laughter != "", o.Laughter != f.Laughter},
+		{"itaiji", o.Itaiji != "", o.Itaiji != f.Itaiji},
+		{"itaiji-dic", o.ItaijiDic != "", o.ItaijiDic != f.ItaijiDic},
+		{"fold", o.Fold != "", o.Fold != f.Fold},
+	}
+	for _, v := range conflicts {
+		if v.isSet && v.isConflict {
+			return fmt.Errorf("option conflicts with the model: [--%s]\nRemove -%s to use the setting of the model", v.name, v.name)
+		}
+	}
+
+	o.Dictionary = s.Dictionary
+	o.UseNeologd = s.UseNeologd
+	o.UseNFKC = s.UseNFKC
+	o.Markup = f.Markup
+	o.UseProtectEntity = f.UseProtectEntity
+	o.UseEmojiName = f.UseEmojiName
+	o.EmojiDic = f.EmojiDic
+	o.Number = f.Number
+	o.Squash = f.Squash
+	o.Laughter = f.Laughter
+	o.Itaiji = f.Itaiji
+	o.ItaijiDic = f.ItaijiDic
+	o.Fold = f.Fold
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"github.com/mkideal/cli"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// compare command
+type compareT struct {
+	cli.Helper
+	CommonOption
+	Reference   string `cli:"ref" usage:"reference input file path to compare with --input"`
+	LabelColumn string `cli:"label" usage:"label column name to separate --input into target and reference"`
+	TargetLabel string `cli:"target" usage:"label value of the target rows (used with --label)"`
+	TopNumber   int    `cli:"top" usage:"show keywords from top by log-likelihood" dft:"100"`
+	MinCount    int    `cli:"mincount" usage:"minimum total count of the word in both corpus" dft:"1"`
+	UseUnique   bool   `cli:"u,unique" usage:"count as one word if the same word exists in a line"`
+}
+
+var compare = &cli.Command{
+	Name: "compare",
+	Desc: "Show characteristic words of the target compared with the reference",
+	Argv: func() interface{} { return new(compareT) },
+	Fn:   execCompare,
+}
+
+func execCompare(ctx *cli.Context) error {
+	argv := ctx.Argv().(*compareT)
+
+	common := ripper.CommonConfig{
+		Column:           argv.Column,
+		ColumnNumber:     argv.ColumnNumber,
+		Input:            argv.Input,
+		Output:           argv.Output,
+		Dictionary:       argv.Dictionary,
+		StopWordPath:     argv.StopWord,
+		ShowResult:       argv.ShowResult,
+		UseOriginalForm:  argv.UseOriginalForm,
+		UseNoun:          argv.UseNoun,
+		UseVerb:          argv.UseVerb,
+		UseAdjective:     argv.UseAdjective,
+		UseNeologd:       argv.UseNeologd,
+		UseNFKC:          argv.UseNFKC,
+		ProgressInterval: argv.ProgressInterval,
+		MinLetterSize:    argv.MinLetterSize,
+		Version:          version,
+		Revision:         revision,
+	}
+	if err := argv.setFilters(&common); err != nil {
+		return err
+	}
+	return ripper.DoCompare(ripper.CompareConfig{
+		CommonConfig: common,
+		Reference:    argv.Reference,
+		LabelColumn:  argv.LabelColumn,
+		TargetLabel:  argv.TargetLabel,
+		TopNumber:    argv.TopNumber,
+		MinCount:     argv.MinCount,
+		UseUnique:    argv.UseUnique,
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"github.com/mkideal/cli"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// cooccur command
+type cooccurT struct {
+	cli.Helper
+	CommonOption
+	WindowSize   int    `cli:"window" usage:"sliding window size of tokens (0 = whole line)"`
+	MinCount     int    `cli:"mincount" usage:"minimum count of the word pair" dft:"2"`
+	MinWordCount int    `cli:"minword" usage:"minimum count of each word in the pair"`
+	TopNumber    int    `cli:"top" usage:"show word pairs from top" dft:"100"`
+	Score        string `cli:"score" usage:"score type to sort the pairs (count, pmi, npmi, dice, tscore)" dft:"count"`
+	GraphOutput  string `cli:"graph" usage:"output file path for graph data --graph='./my_graph.graphml' (.graphml or .gexf)"`
+}
+
+var cooccur = &cli.Command{
+	Name: "cooccur",
+	Desc: "Show co-occurrence of the word pairs",
+	Argv: func() interface{} { return new(cooccurT) },
+	Fn:   execCooccur,
+}
+
+func execCooccur(ctx *cli.Context) error {
+	argv := ctx.Argv().(*cooccurT)
+
+	common := ripper.CommonConfig{
+		Column:           argv.Column,
+		ColumnNumber:     argv.ColumnNumber,
+		Input:            argv.Input,
+		Output:           argv.Output,
+		Dictionary:       argv.Dictionary,
+		StopWordPath:     argv.StopWord,
+		ShowResult:       argv.ShowResult,
+		UseOriginalForm:  argv.UseOriginalForm,
+		UseNoun:          argv.UseNoun,
+		UseVerb:          argv.UseVerb,
+		UseAdjective:     argv.UseAdjective,
+		UseNeologd:       argv.UseNeologd,
+		UseNFKC:          argv.UseNFKC,
+		ProgressInterval: argv.ProgressInterval,
+		MinLetterSize:    argv.MinLetterSize,
+		Version:          version,
+		Revision:         revision,
+	}
+	if err := argv.setFilters(&common); err != nil {
+		return err
+	}
+	return ripper.DoCooccur(ripper.CooccurConfig{
+		CommonConfig: common,
+		WindowSize:   argv.WindowSize,
+		MinCount:     argv.MinCount,
+		MinWordCount: argv.MinWordCount,
+		TopNumber:    argv.TopNumber,
+		Score:        argv.Score,
+		GraphOutput:  argv.GraphOutput,
+	})
+}
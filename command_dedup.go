@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/mkideal/cli"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// dedup command
+type dedupT struct {
+	cli.Helper
+	CommonOption
+	Prefix      string  `cli:"prefix" usage:"prefix name for new columns"`
+	Mode        string  `cli:"mode" usage:"output mode (cluster: add cluster id columns, remove: remove duplicate rows)" dft:"cluster"`
+	ShingleSize int     `cli:"shingle" usage:"word size of a shingle" dft:"3"`
+	HashSize    int     `cli:"hash" usage:"number of hash functions for MinHash" dft:"64"`
+	BandSize    int     `cli:"band" usage:"number of bands for LSH (--hash must be divisible by this)" dft:"16"`
+	Threshold   float64 `cli:"threshold" usage:"minimum jaccard similarity to be treated as duplicate (0.0 ~ 1.0)" dft:"0.8"`
+	Seed        int64   `cli:"seed" usage:"seed for hash functions"`
+}
+
+var dedup = &cli.Command{
+	Name: "dedup",
+	Desc: "Detect near-duplicate rows by MinHash",
+	Argv: func() interface{} { return new(dedupT) },
+	Fn:   execDedup,
+}
+
+func execDedup(ctx *cli.Context) error {
+	argv := ctx.Argv().(*dedupT)
+
+	common := ripper.CommonConfig{
+		Column:           argv.Column,
+		ColumnNumber:     argv.ColumnNumber,
+		Input:            argv.Input,
+		Output:           argv.Output,
+		Dictionary:       argv.Dictionary,
+		StopWordPath:     argv.StopWord,
+		ShowResult:       argv.ShowResult,
+		UseOriginalForm:  argv.UseOriginalForm,
+		UseNoun:          argv.UseNoun,
+		UseVerb:          argv.UseVerb,
+		UseAdjective:     argv.UseAdjective,
+		UseNeologd:       argv.UseNeologd,
+		UseNFKC:          argv.UseNFKC,
+		ProgressInterval: argv.ProgressInterval,
+		MinLetterSize:    argv.MinLetterSize,
+		Version:          version,
+		Revision:         revision,
+		Prefix:           argv.Prefix,
+	}
+	if err := argv.setFilters(&common); err != nil {
+		return err
+	}
+	return ripper.DoDedup(ripper.DedupConfig{
+		CommonConfig: common,
+		Mode:         argv.Mode,
+		ShingleSize:  argv.ShingleSize,
+		HashSize:     argv.HashSize,
+		BandSize:     argv.BandSize,
+		Threshold:    argv.Threshold,
+		Seed:         argv.Seed,
+	})
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"github.com/mkideal/cli"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// kwic command
+type kwicT struct {
+	cli.Helper
+	CommonOption
+	Query       string `cli:"q,query" usage:"query word to search"`
+	UseRegexp   bool   `cli:"regexp" usage:"use query as regular expression"`
+	PosPattern  string `cli:"pos" usage:"features of the part of speech to search (separated by comma) --pos='名詞,固有名詞'"`
+	ContextSize int    `cli:"context" usage:"token size of left and right context" dft:"5"`
+	IDColumn    string `cli:"id" usage:"column name to output as an id of the row"`
+}
+
+var kwic = &cli.Command{
+	Name: "kwic",
+	Desc: "Show keyword-in-context concordance",
+	Argv: func() interface{} { return new(kwicT) },
+	Fn:   execKWIC,
+}
+
+func execKWIC(ctx *cli.Context) error {
+	argv := ctx.Argv().(*kwicT)
+
+	common := ripper.CommonConfig{
+		Column:           argv.Column,
+		ColumnNumber:     argv.ColumnNumber,
+		Input:            argv.Input,
+		Output:           argv.Output,
+		Dictionary:       argv.Dictionary,
+		StopWordPath:     argv.StopWord,
+		ShowResult:       argv.ShowResult,
+		UseOriginalForm:  argv.UseOriginalForm,
+		UseNoun:          argv.UseNoun,
+		UseVerb:          argv.UseVerb,
+		UseAdjective:     argv.UseAdjective,
+		UseNeologd:       argv.UseNeologd,
+		UseNFKC:          argv.UseNFKC,
+		ProgressInterval: argv.ProgressInterval,
+		MinLetterSize:    argv.MinLetterSize,
+		Version:          version,
+		Revision:         revision,
+	}
+	if err := argv.setFilters(&common); err != nil {
+		return err
+	}
+	return ripper.DoKWIC(ripper.KWICConfig{
+		CommonConfig: common,
+		Query:        argv.Query,
+		UseRegexp:    argv.UseRegexp,
+		PosPattern:   argv.PosPattern,
+		ContextSize:  argv.ContextSize,
+		IDColumn:     argv.IDColumn,
+	})
+}
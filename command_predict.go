@@ -0,0 +1,61 @@
+package main
+
+import (
+	"github.com/mkideal/cli"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// predict command
+type predictT struct {
+	cli.Helper
+	CommonOption
+	Prefix string `cli:"prefix" usage:"prefix name for new columns"`
+	Model  string `cli:"m,model" usage:"model file path created by train command --model='./model.json'"`
+}
+
+var predict = &cli.Command{
+	Name: "predict",
+	Desc: "Predict label by Naive Bayes text classifier",
+	Argv: func() interface{} { return new(predictT) },
+	Fn:   execPredict,
+}
+
+func execPredict(ctx *cli.Context) error {
+	argv := ctx.Argv().(*predictT)
+
+	common := ripper.CommonConfig{
+		Column:           argv.Column,
+		ColumnNumber:     argv.ColumnNumber,
+		Input:            argv.Input,
+		Output:           argv.Output,
+		Dictionary:       argv.Dictionary,
+		ShowResult:       argv.ShowResult,
+		ProgressInterval: argv.ProgressInterval,
+		Prefix:           argv.Prefix,
+		Version:          version,
+		Revision:         revision,
+	}
+
+	// tokenizer settings are loaded from the model
+	var model *ripper.NaiveBayesModel
+	if argv.Model != "" {
+		var err error
+		model, err = ripper.LoadNaiveBayesModel(argv.Model)
+		if err != nil {
+			return err
+		}
+		if err := argv.applyModelSetting(model.Tokenizer); err != nil {
+			return err
+		}
+		model.Tokenizer.Apply(&common)
+	}
+	if err := argv.setFilters(&common); err != nil {
+		return err
+	}
+	return ripper.DoPredict(ripper.PredictConfig{
+		CommonConfig: common,
+		Model:        argv.Model,
+		NaiveBayes:   model,
+	})
+}
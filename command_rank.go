@@ -1,12 +1,21 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/mkideal/cli"
 
 	"github.com/evalphobia/go-jp-text-ripper/prefilter"
 	"github.com/evalphobia/go-jp-text-ripper/ripper"
 )
 
+// entity types for --entity option.
+const (
+	entityHashtag = "hashtag"
+	entityMention = "mention"
+	entityDomain  = "domain"
+)
+
 // rank command
 type rankT struct {
 	cli.Helper
@@ -16,6 +25,10 @@ type rankT struct {
 	LastNumber  int     `cli:"last" usage:"rank from last by count"`
 	LastPercent float64 `cli:"lastp" usage:"rank from last by percent (0.0 ~ 1.0)"`
 	UseUnique   bool    `cli:"u,unique" usage:"count as one word if the same word exists in a line"`
+	Score       string  `cli:"score" usage:"score type to sort the ranking (count, df, tfidf, bm25)" dft:"count"`
+	GroupBy     string  `cli:"group-by" usage:"column name to separate the ranking by its value"`
+	MaxGroups   int     `cli:"maxgroup" usage:"maximum number of groups for --group-by (negative value means unlimited)" dft:"1000"`
+	Entity      string  `cli:"entity" usage:"rank the entities instead of words (hashtag, mention, domain)"`
 }
 
 var rank = &cli.Command{
@@ -41,20 +54,50 @@ func execRank(ctx *cli.Context) error {
 		UseVerb:          argv.UseVerb,
 		UseAdjective:     argv.UseAdjective,
 		UseNeologd:       argv.UseNeologd,
+		UseNFKC:          argv.UseNFKC,
 		ProgressInterval: argv.ProgressInterval,
 		MinLetterSize:    argv.MinLetterSize,
 		Version:          version,
 		Revision:         revision,
 	}
-	if common.UseNeologd {
-		common.PreFilters = append(common.PreFilters, prefilter.Neologd)
+	if err := argv.setFilters(&common); err != nil {
+		return err
+	}
+	extractor, err := getEntityExtractor(argv.Entity)
+	if err != nil {
+		return err
 	}
 	return ripper.DoRank(ripper.RankConfig{
-		CommonConfig: common,
-		TopNumber:    argv.TopNumber,
-		TopPercent:   argv.TopPercent,
-		LastNumber:   argv.LastNumber,
-		LastPercent:  argv.LastPercent,
-		UseUnique:    argv.UseUnique,
+		CommonConfig:  common,
+		TopNumber:     argv.TopNumber,
+		TopPercent:    argv.TopPercent,
+		LastNumber:    argv.LastNumber,
+		LastPercent:   argv.LastPercent,
+		UseUnique:     argv.UseUnique,
+		Score:         argv.Score,
+		GroupBy:       argv.GroupBy,
+		MaxGroups:     argv.MaxGroups,
+		TermExtractor: extractor,
 	})
 }
+
+// getEntityExtractor returns the function to extract the entities for --entity option.
+func getEntityExtractor(entity string) (func(string) []string, error) {
+	switch entity {
+	case "":
+		return nil, nil
+	case entityHashtag:
+		return func(s string) []string {
+			return prefilter.ExtractEntities(s).Hashtags
+		}, nil
+	case entityMention:
+		return func(s string) []string {
+			return prefilter.ExtractEntities(s).Mentions
+		}, nil
+	case entityDomain:
+		return func(s string) []string {
+			return prefilter.ExtractEntities(s).Domains()
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid entity type: [%s]\nSet -entity <hashtag|mention|domain>", entity)
+}
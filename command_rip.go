@@ -1,10 +1,12 @@
 package main
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/mkideal/cli"
 
+	"github.com/evalphobia/go-jp-text-ripper/plugin"
 	"github.com/evalphobia/go-jp-text-ripper/prefilter"
 	"github.com/evalphobia/go-jp-text-ripper/ripper"
 )
@@ -23,6 +25,25 @@ type ripT struct {
 	StopWordLastNumber  int     `cli:"stoplast" usage:"use ranking from last as stopword"`
 	StopWordLastPercent float64 `cli:"stoplastp" usage:"use ranking from last by percent as stopword (0.0 ~ 1.0)"`
 	UseStopWordUnique   bool    `cli:"stopunique" usage:"use ranking stopword as unique per line"`
+	Format              string  `cli:"format" usage:"output format (table, fasttext, plain)" dft:"table"`
+	LabelColumn         string  `cli:"label-column" usage:"label column name for fasttext format"`
+	UseLowercase        bool    `cli:"lower" usage:"convert words into lower case"`
+	UseNumberMask       bool    `cli:"masknum" usage:"replace number words with '<NUM>' (same as --number mask)"`
+	Unit                string  `cli:"unit" usage:"unit of the text to output a row (line, sentence)" dft:"line"`
+	KeywordNumber       int     `cli:"keyword" usage:"output top N keywords of each row into 'keywords' column"`
+	KeywordMethod       string  `cli:"keyword-method" usage:"keyword extraction method (textrank, rake)" dft:"textrank"`
+	UseKeywordIDF       bool    `cli:"keyword-idf" usage:"weight keyword score by idf of the input corpus"`
+	UseAozoraRuby       bool    `cli:"aozora-ruby" usage:"output ruby readings of Aozora Bunko text"`
+	UseEmoji            bool    `cli:"emoji" usage:"output emoji and kaomoji count and the list"`
+	UseEntity           bool    `cli:"entity" usage:"output url, email, hashtag and mention count, and the lists of hashtags, mentions and domains"`
+	UseNumbers          bool    `cli:"numbers" usage:"output number expression count and the values as arabic numerals"`
+	UseDateTime         bool    `cli:"datetime" usage:"output date and time expressions as ISO-8601 format"`
+	ReferenceDate       string  `cli:"reference-date" usage:"base date to resolve relative date expressions --reference-date='2024-03-01' (default: today)"`
+	ReferenceColumn     string  `cli:"reference-column" usage:"column name of the base date of each row for relative date expressions"`
+	UseAddress          bool    `cli:"address" usage:"output addresses, prefectures, municipalities and postal codes"`
+	AddressDic          string  `cli:"address-dic" usage:"municipality list file path to validate all municipalities (used with --address)"`
+	UsePII              bool    `cli:"pii" usage:"mask personal data (email, phone, postal code, card number, url and person name)"`
+	SentimentLexicon    string  `cli:"sentiment" usage:"polarity dictionary file path to output sentiment score --sentiment='./lexicon.tsv'"`
 }
 
 var rip = &cli.Command{
@@ -48,6 +69,7 @@ func execRip(ctx *cli.Context) error {
 		UseVerb:          argv.UseVerb,
 		UseAdjective:     argv.UseAdjective,
 		UseNeologd:       argv.UseNeologd,
+		UseNFKC:          argv.UseNFKC,
 		ProgressInterval: argv.ProgressInterval,
 		MinLetterSize:    argv.MinLetterSize,
 		Version:          version,
@@ -55,10 +77,94 @@ func execRip(ctx *cli.Context) error {
 		Prefix:           argv.Prefix,
 		Debug:            argv.Debug,
 	}
-	if common.UseNeologd {
-		common.PreFilters = append(common.PreFilters, prefilter.Neologd)
+	if argv.UseNumberMask {
+		// '--masknum' is the same as '--number mask'
+		switch argv.Number {
+		case "", numberMask:
+			argv.Number = numberMask
+		default:
+			return fmt.Errorf("option conflicts: [--masknum] and [--number %s]\nRemove -masknum to use -number %s", argv.Number, argv.Number)
+		}
 	}
-	return ripper.DoRip(ripper.RipConfig{
+	var maskFilters []*ripper.PreFilter
+	if argv.UsePII {
+		// mask personal data after the markup removal, before the other prefilters rewrite digits
+		maskFilters = append(maskFilters, prefilter.PII)
+	}
+	if err := argv.setFilters(&common, maskFilters...); err != nil {
+		return err
+	}
+	if argv.UseAozoraRuby {
+		common.Plugins = append(common.Plugins, plugin.AozoraRubyPlugin)
+	}
+	if argv.UseEmoji {
+		common.Plugins = append(common.Plugins, plugin.EmojiPlugins...)
+	}
+	if argv.UseEntity {
+		common.Plugins = append(common.Plugins, plugin.NewEntityPlugins()...)
+	}
+	if argv.UseNumbers {
+		common.Plugins = append(common.Plugins, plugin.NumberPlugins...)
+	}
+	if argv.UseDateTime {
+		conf := plugin.DateTimeConfig{
+			ReferenceColumn: argv.ReferenceColumn,
+		}
+		if argv.ReferenceDate != "" {
+			t, ok := plugin.ParseReferenceDate(argv.ReferenceDate)
+			if !ok {
+				return fmt.Errorf("invalid reference date: [%s]\nSet -reference-date <YYYY-MM-DD>", argv.ReferenceDate)
+			}
+			conf.ReferenceDate = t
+		}
+		common.Plugins = append(common.Plugins, plugin.NewDateTimePlugin(conf))
+	}
+	if argv.UseAddress {
+		list := plugin.NewMunicipalityList()
+		if argv.AddressDic != "" {
+			if err := list.LoadFile(argv.AddressDic); err != nil {
+				return err
+			}
+		}
+		common.Plugins = append(common.Plugins, plugin.NewAddressPlugins(list)...)
+	}
+	if argv.UsePII {
+		// count personal data on the text after the markup removal, same as the prefilter
+		markup, err := argv.markupFilters()
+		if err != nil {
+			return err
+		}
+		common.Plugins = append(common.Plugins, plugin.NewPIIPlugins(markup...)...)
+	}
+	if argv.KeywordNumber > 0 {
+		switch argv.KeywordMethod {
+		case plugin.KeywordTextRank,
+			plugin.KeywordRAKE:
+		default:
+			return fmt.Errorf("invalid keyword method: [%s]\nSet -keyword-method <textrank|rake>", argv.KeywordMethod)
+		}
+		conf := plugin.KeywordConfig{
+			Method:    argv.KeywordMethod,
+			TopNumber: argv.KeywordNumber,
+		}
+		if argv.UseKeywordIDF {
+			// first pass to get idf from the input
+			idf, err := ripper.GetCorpusIDF(common)
+			if err != nil {
+				return err
+			}
+			conf.IDF = idf
+		}
+		common.Plugins = append(common.Plugins, plugin.NewKeywordPlugin(conf))
+	}
+	if argv.SentimentLexicon != "" {
+		lex, err := plugin.LoadSentimentLexicon(argv.SentimentLexicon)
+		if err != nil {
+			return err
+		}
+		common.Plugins = append(common.Plugins, plugin.NewSentimentPlugins(lex)...)
+	}
+	conf := ripper.RipConfig{
 		CommonConfig:        common,
 		ReplaceText:         argv.ReplaceText,
 		Quotes:              strings.Split(argv.Quote, ","),
@@ -68,5 +174,15 @@ func execRip(ctx *cli.Context) error {
 		StopWordLastNumber:  argv.StopWordLastNumber,
 		StopWordLastPercent: argv.StopWordLastPercent,
 		UseStopWordUnique:   argv.UseStopWordUnique,
-	})
+		Format:              argv.Format,
+		LabelColumn:         argv.LabelColumn,
+		UseLowercase:        argv.UseLowercase,
+		Unit:                argv.Unit,
+	}
+	if argv.UsePII {
+		// the target column and words are also masked not to leak personal data
+		conf.TextMask = plugin.MaskPIIText
+		conf.WordMask = plugin.MaskPIIWord
+	}
+	return ripper.DoRip(conf)
 }
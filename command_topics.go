@@ -0,0 +1,76 @@
+package main
+
+import (
+	"github.com/mkideal/cli"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// topics command
+type topicsT struct {
+	cli.Helper
+	CommonOption
+	Prefix              string  `cli:"prefix" usage:"prefix name for new columns"`
+	TopicSize           int     `cli:"k,topic" usage:"number of topics" dft:"10"`
+	Iterations          int     `cli:"iter" usage:"number of iterations for Gibbs sampling" dft:"100"`
+	Seed                int64   `cli:"seed" usage:"seed for random number"`
+	Alpha               float64 `cli:"alpha" usage:"hyper parameter of document-topic distribution (default=50/topic)"`
+	Beta                float64 `cli:"beta" usage:"hyper parameter of topic-word distribution" dft:"0.01"`
+	TopicOutput         string  `cli:"topic-output" usage:"output file path of top words per topic --topic-output='./topics.csv'"`
+	TopicWordNumber     int     `cli:"topicword" usage:"number of top words per topic" dft:"10"`
+	StopWordTopNumber   int     `cli:"stoptop" usage:"use ranking from top as stopword"`
+	StopWordTopPercent  float64 `cli:"stoptopp" usage:"use ranking from top by percent as stopword (0.0 ~ 1.0)"`
+	StopWordLastNumber  int     `cli:"stoplast" usage:"use ranking from last as stopword"`
+	StopWordLastPercent float64 `cli:"stoplastp" usage:"use ranking from last by percent as stopword (0.0 ~ 1.0)"`
+	UseStopWordUnique   bool    `cli:"stopunique" usage:"use ranking stopword as unique per line"`
+}
+
+var topics = &cli.Command{
+	Name: "topics",
+	Desc: "Train LDA topic model",
+	Argv: func() interface{} { return new(topicsT) },
+	Fn:   execTopics,
+}
+
+func execTopics(ctx *cli.Context) error {
+	argv := ctx.Argv().(*topicsT)
+
+	common := ripper.CommonConfig{
+		Column:           argv.Column,
+		ColumnNumber:     argv.ColumnNumber,
+		Input:            argv.Input,
+		Output:           argv.Output,
+		Dictionary:       argv.Dictionary,
+		StopWordPath:     argv.StopWord,
+		ShowResult:       argv.ShowResult,
+		UseOriginalForm:  argv.UseOriginalForm,
+		UseNoun:          argv.UseNoun,
+		UseVerb:          argv.UseVerb,
+		UseAdjective:     argv.UseAdjective,
+		UseNeologd:       argv.UseNeologd,
+		UseNFKC:          argv.UseNFKC,
+		ProgressInterval: argv.ProgressInterval,
+		MinLetterSize:    argv.MinLetterSize,
+		Version:          version,
+		Revision:         revision,
+		Prefix:           argv.Prefix,
+	}
+	if err := argv.setFilters(&common); err != nil {
+		return err
+	}
+	return ripper.DoTopics(ripper.TopicsConfig{
+		CommonConfig:        common,
+		TopicSize:           argv.TopicSize,
+		Iterations:          argv.Iterations,
+		Seed:                argv.Seed,
+		Alpha:               argv.Alpha,
+		Beta:                argv.Beta,
+		TopicOutput:         argv.TopicOutput,
+		TopicWordNumber:     argv.TopicWordNumber,
+		StopWordTopNumber:   argv.StopWordTopNumber,
+		StopWordTopPercent:  argv.StopWordTopPercent,
+		StopWordLastNumber:  argv.StopWordLastNumber,
+		StopWordLastPercent: argv.StopWordLastPercent,
+		UseStopWordUnique:   argv.UseStopWordUnique,
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"github.com/mkideal/cli"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// train command
+type trainT struct {
+	cli.Helper
+	CommonOption
+	LabelColumn string  `cli:"label" usage:"label column name to train"`
+	Model       string  `cli:"m,model" usage:"output file path of the model --model='./model.json'"`
+	Alpha       float64 `cli:"alpha" usage:"smoothing parameter of Naive Bayes" dft:"1.0"`
+	KFold       int     `cli:"kfold" usage:"number of folds for cross validation (evaluation result is written to --output)"`
+	Seed        int64   `cli:"seed" usage:"seed for random number to split folds"`
+}
+
+var train = &cli.Command{
+	Name: "train",
+	Desc: "Train Naive Bayes text classifier",
+	Argv: func() interface{} { return new(trainT) },
+	Fn:   execTrain,
+}
+
+func execTrain(ctx *cli.Context) error {
+	argv := ctx.Argv().(*trainT)
+
+	common := ripper.CommonConfig{
+		Column:           argv.Column,
+		ColumnNumber:     argv.ColumnNumber,
+		Input:            argv.Input,
+		Output:           argv.Output,
+		Dictionary:       argv.Dictionary,
+		StopWordPath:     argv.StopWord,
+		ShowResult:       argv.ShowResult,
+		UseOriginalForm:  argv.UseOriginalForm,
+		UseNoun:          argv.UseNoun,
+		UseVerb:          argv.UseVerb,
+		UseAdjective:     argv.UseAdjective,
+		UseNeologd:       argv.UseNeologd,
+		UseNFKC:          argv.UseNFKC,
+		ProgressInterval: argv.ProgressInterval,
+		MinLetterSize:    argv.MinLetterSize,
+		Version:          version,
+		Revision:         revision,
+	}
+	if err := argv.setFilters(&common); err != nil {
+		return err
+	}
+	return ripper.DoTrain(ripper.TrainConfig{
+		CommonConfig: common,
+		LabelColumn:  argv.LabelColumn,
+		ModelOutput:  argv.Model,
+		Alpha:        argv.Alpha,
+		Filter:       argv.filterSetting(),
+		KFold:        argv.KFold,
+		Seed:         argv.Seed,
+	})
+}
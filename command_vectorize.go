@@ -0,0 +1,68 @@
+package main
+
+import (
+	"github.com/mkideal/cli"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// vectorize command
+type vectorizeT struct {
+	cli.Helper
+	CommonOption
+	Format           string  `cli:"format" usage:"output format of the matrix (mm, libsvm)" dft:"mm"`
+	Weight           string  `cli:"weight" usage:"weight of the matrix value (count, binary, tfidf)" dft:"count"`
+	LabelColumn      string  `cli:"label" usage:"label column name for libsvm format"`
+	VocabularyOutput string  `cli:"vocab-output" usage:"output file path of the vocabulary --vocab-output='./vocab.tsv'"`
+	Vocabulary       string  `cli:"vocab" usage:"saved vocabulary file path to use instead of building from the input"`
+	MinDF            int     `cli:"mindf" usage:"ignore words which document frequency is lower than this" dft:"1"`
+	MaxDF            float64 `cli:"maxdf" usage:"ignore words which document frequency ratio is higher than this (0.0 ~ 1.0)" dft:"1.0"`
+	MaxFeatures      int     `cli:"maxfeatures" usage:"use top N words by the frequency as the vocabulary"`
+	UseUnique        bool    `cli:"u,unique" usage:"count as one word if the same word exists in a line (for --maxfeatures)"`
+}
+
+var vectorize = &cli.Command{
+	Name: "vectorize",
+	Desc: "Export bag-of-words matrix for machine learning",
+	Argv: func() interface{} { return new(vectorizeT) },
+	Fn:   execVectorize,
+}
+
+func execVectorize(ctx *cli.Context) error {
+	argv := ctx.Argv().(*vectorizeT)
+
+	common := ripper.CommonConfig{
+		Column:           argv.Column,
+		ColumnNumber:     argv.ColumnNumber,
+		Input:            argv.Input,
+		Output:           argv.Output,
+		Dictionary:       argv.Dictionary,
+		StopWordPath:     argv.StopWord,
+		ShowResult:       argv.ShowResult,
+		UseOriginalForm:  argv.UseOriginalForm,
+		UseNoun:          argv.UseNoun,
+		UseVerb:          argv.UseVerb,
+		UseAdjective:     argv.UseAdjective,
+		UseNeologd:       argv.UseNeologd,
+		UseNFKC:          argv.UseNFKC,
+		ProgressInterval: argv.ProgressInterval,
+		MinLetterSize:    argv.MinLetterSize,
+		Version:          version,
+		Revision:         revision,
+	}
+	if err := argv.setFilters(&common); err != nil {
+		return err
+	}
+	return ripper.DoVectorize(ripper.VectorizeConfig{
+		CommonConfig:     common,
+		Format:           argv.Format,
+		Weight:           argv.Weight,
+		LabelColumn:      argv.LabelColumn,
+		VocabularyOutput: argv.VocabularyOutput,
+		Vocabulary:       argv.Vocabulary,
+		MinDF:            argv.MinDF,
+		MaxDF:            argv.MaxDF,
+		MaxFeatures:      argv.MaxFeatures,
+		UseUnique:        argv.UseUnique,
+	})
+}
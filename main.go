@@ -17,6 +17,14 @@ func main() {
 		cli.Tree(help),
 		cli.Tree(rip),
 		cli.Tree(rank),
+		cli.Tree(compare),
+		cli.Tree(cooccur),
+		cli.Tree(kwic),
+		cli.Tree(vectorize),
+		cli.Tree(dedup),
+		cli.Tree(topics),
+		cli.Tree(train),
+		cli.Tree(predict),
 	).Run(os.Args[1:]); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -28,7 +36,7 @@ var help = cli.HelpCommand("show help")
 // main command
 var root = &cli.Command{
 	Fn: func(ctx *cli.Context) error {
-		ctx.String(ctx.Command().Usage(ctx))
+		ctx.String("%s", ctx.Command().Usage(ctx))
 		return nil
 	},
 }
@@ -0,0 +1,395 @@
+package plugin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+const (
+	// maximum length of municipality name (without suffix)
+	maxMunicipalityNameLength = 7
+	// maximum length of ward name in the designated city (without suffix)
+	maxWardNameLength = 4
+)
+
+var (
+	reAddressTail = regexp.MustCompile(`^(?:[一-龯々〆ヵヶケァ-ヴー]{0,12}?(?:(?:[0-9]+|[一二三四五六七八九十〇]+)(?:丁目|番地|番|号|条|-|の))+(?:[0-9]+|[一二三四五六七八九十〇]+)?|[一-龯々〆ヵヶケァ-ヴー]{0,12}?[0-9]+)+`)
+	rePostalCode  = regexp.MustCompile(`〒\s?([0-9]{3})-?([0-9]{4})|([0-9]{3})-([0-9]{4})`)
+)
+
+// Address is an address in the text.
+type Address struct {
+	Text         string
+	Prefecture   string
+	Municipality string
+}
+
+// MunicipalityList is the list of prefectures and municipalities to find the addresses.
+// The bundled list contains only prefectures, designated cities (政令指定都市) and special wards of Tokyo,
+// and the other municipalities are detected by the suffix without validation until the list file is loaded.
+type MunicipalityList struct {
+	// municipality => prefecture
+	municipalities map[string]string
+	prefectureRe   *regexp.Regexp
+	designatedRe   *regexp.Regexp
+	// validate municipalities by the list, and it's true when the list file is loaded.
+	strict bool
+}
+
+// NewMunicipalityList returns the bundled list.
+func NewMunicipalityList() *MunicipalityList {
+	l := &MunicipalityList{
+		municipalities: make(map[string]string),
+	}
+	for pref, list := range bundledMunicipalities {
+		for _, m := range list {
+			l.municipalities[m] = pref
+		}
+	}
+
+	designated := make([]string, 0, len(bundledMunicipalities))
+	for _, list := range bundledMunicipalities {
+		for _, m := range list {
+			if strings.HasSuffix(m, "市") {
+				designated = append(designated, m)
+			}
+		}
+	}
+	l.prefectureRe = regexp.MustCompile(strings.Join(prefectures, "|"))
+	l.designatedRe = regexp.MustCompile(strings.Join(designated, "|"))
+	return l
+}
+
+// LoadMunicipalityList returns the list with the bundled list and the file.
+// The file is TSV format, and each line contains a prefecture and a municipality (e.g. "神奈川県\t横浜市中区").
+// When the file is loaded, municipalities are validated by the list.
+func LoadMunicipalityList(path string) (*MunicipalityList, error) {
+	l := NewMunicipalityList()
+	if err := l.LoadFile(path); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// LoadFile adds municipalities from the file.
+func (l *MunicipalityList) LoadFile(path string) error {
+	/* #nosec G304 */
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close() // #nosec G307
+
+	return l.Load(fp)
+}
+
+// Load adds municipalities from io.Reader.
+// Empty lines and lines starting with '#' are ignored.
+func (l *MunicipalityList) Load(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cols := strings.Fields(line)
+		if len(cols) < 2 || !isPrefecture(cols[0]) {
+			return fmt.Errorf("invalid municipality format on line:[%d] text:[%s]", lineNo, line)
+		}
+		l.municipalities[cols[1]] = cols[0]
+	}
+	l.strict = true
+	return sc.Err()
+}
+
+// FindAddresses returns addresses which start with a prefecture or a designated city in the text.
+// (e.g. '東京都港区芝公園4丁目2番8号', '横浜市中区山下町1-1')
+func (l *MunicipalityList) FindAddresses(s string) []Address {
+	s = normalizeAddressText(s)
+
+	var list []Address
+	for pos := 0; pos < len(s); {
+		idx := l.prefectureRe.FindStringIndex(s[pos:])
+		if cityIdx := l.designatedRe.FindStringIndex(s[pos:]); cityIdx != nil && (idx == nil || cityIdx[0] < idx[0]) {
+			// address without prefecture
+			idx = []int{cityIdx[0], cityIdx[0]}
+		}
+		if idx == nil {
+			break
+		}
+
+		start, prefEnd := pos+idx[0], pos+idx[1]
+		pref := s[start:prefEnd]
+		muni := l.findMunicipality(s[prefEnd:], pref)
+		if muni == "" {
+			pos = prefEnd
+			if prefEnd == start {
+				_, size := utf8.DecodeRuneInString(s[start:])
+				pos += size
+			}
+			continue
+		}
+		if pref == "" {
+			pref = l.getPrefecture(muni)
+		}
+
+		end := prefEnd + len(muni)
+		end += len(reAddressTail.FindString(s[end:]))
+		list = append(list, Address{
+			Text:         s[start:end],
+			Prefecture:   pref,
+			Municipality: muni,
+		})
+		pos = end
+	}
+	return list
+}
+
+// getPrefecture returns the prefecture of the municipality (including ward of the designated city) from the list.
+func (l *MunicipalityList) getPrefecture(muni string) string {
+	if pref, ok := l.municipalities[muni]; ok {
+		return pref
+	}
+	if i := strings.Index(muni, "市"); i >= 0 {
+		return l.municipalities[muni[:i+len("市")]]
+	}
+	return ""
+}
+
+// findMunicipality returns the municipality from the beginning of the text.
+func (l *MunicipalityList) findMunicipality(s, pref string) string {
+	runes := []rune(s)
+	if len(runes) > 12 {
+		runes = runes[:12]
+	}
+
+	// longest municipality in the list, and ward of the designated city
+	if muni := l.findListedMunicipality(runes, pref); muni != "" || l.strict {
+		return muni
+	}
+
+	// 'X郡Y町', 'X郡Y村'
+	if i := indexSuffix(runes, 0, "郡", maxMunicipalityNameLength); i > 0 {
+		if j := indexSuffix(runes, i+1, "町村", maxMunicipalityNameLength); j > 0 {
+			return string(runes[:j+1])
+		}
+	}
+
+	// the rest of municipalities need the prefecture
+	if pref == "" {
+		return ""
+	}
+
+	// 'X市'
+	if i := indexSuffix(runes, 0, "市", maxMunicipalityNameLength); i > 0 {
+		end := i + 1
+		if end < len(runes) && runes[end] == '市' {
+			// e.g. '四日市市', '廿日市市'
+			end++
+		}
+		return string(runes[:end])
+	}
+
+	// 'X町', 'X村' without county
+	if i := indexSuffix(runes, 0, "町村", maxMunicipalityNameLength); i > 0 {
+		return string(runes[:i+1])
+	}
+	return ""
+}
+
+// findListedMunicipality returns the longest municipality in the list from the beginning of the text.
+func (l *MunicipalityList) findListedMunicipality(runes []rune, pref string) string {
+	for n := len(runes); n >= 2; n-- {
+		candidate := string(runes[:n])
+		p, ok := l.municipalities[candidate]
+		if !ok || (pref != "" && p != pref) {
+			continue
+		}
+		if !l.strict && strings.HasSuffix(candidate, "市") {
+			// ward of the designated city (e.g. '京都市中京区')
+			if j := indexSuffix(runes, n, "区", maxWardNameLength); j > 0 {
+				return string(runes[:j+1])
+			}
+		}
+		return candidate
+	}
+	return ""
+}
+
+// indexSuffix returns the first position of the suffix after the name (at least one character).
+// names containing the other administrative units are ignored (e.g. '新宿区市谷'),
+// and names starting with a particle are ignored (e.g. '東京都では').
+// this is used only for the names which are not in the list, so the names containing '都' or '府' (e.g. '宇都宮市') are allowed.
+func indexSuffix(runes []rune, start int, suffixes string, maxLength int) int {
+	if start+1 < len(runes) && strings.ContainsRune("はのでにをがへともや", runes[start]) {
+		return -1
+	}
+	for i := start + 1; i < len(runes) && i <= start+maxLength; i++ {
+		r := runes[i]
+		switch {
+		case strings.ContainsRune(suffixes, r):
+			return i
+		case strings.ContainsRune("県郡区", r),
+			!isAddressNameRune(r):
+			return -1
+		}
+	}
+	return -1
+}
+
+func isAddressNameRune(r rune) bool {
+	switch {
+	case '一' <= r && r <= '龯',
+		'ァ' <= r && r <= 'ヶ',
+		'ぁ' <= r && r <= 'ゖ',
+		strings.ContainsRune("々〆ー", r):
+		return true
+	}
+	return false
+}
+
+func isPrefecture(s string) bool {
+	for _, p := range prefectures {
+		if p == s {
+			return true
+		}
+	}
+	return false
+}
+
+// FindPostalCodes returns postal codes in the text as 'NNN-NNNN' format.
+// Numbers without hyphen need the postal mark (e.g. '〒1000001').
+func FindPostalCodes(s string) []string {
+	s = normalizeAddressText(s)
+
+	var list []string
+	for _, m := range rePostalCode.FindAllStringSubmatchIndex(s, -1) {
+		start, end := m[0], m[1]
+		if !isPostalBoundary(s, start, end) {
+			continue
+		}
+		if m[2] >= 0 {
+			list = append(list, s[m[2]:m[3]]+"-"+s[m[4]:m[5]])
+			continue
+		}
+		list = append(list, s[m[6]:m[7]]+"-"+s[m[8]:m[9]])
+	}
+	return list
+}
+
+// isPostalBoundary checks the previous and next characters are not digit or hyphen (e.g. phone number).
+func isPostalBoundary(s string, start, end int) bool {
+	prev, _ := utf8.DecodeLastRuneInString(s[:start])
+	next, _ := utf8.DecodeRuneInString(s[end:])
+	for _, r := range []rune{prev, next} {
+		if ('0' <= r && r <= '9') || r == '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeAddressText converts full-width digits and hyphens into half-width.
+func normalizeAddressText(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case '０' <= r && r <= '９':
+			return r - '０' + '0'
+		case strings.ContainsRune("－‐−―‒–", r):
+			return '-'
+		}
+		return r
+	}, s)
+}
+
+// NewAddressPlugins returns the plugins to output addresses, prefectures, municipalities and postal codes in raw text.
+// Each value is separated by space, and the prefectures and municipalities are in the same order of the addresses.
+func NewAddressPlugins(l *MunicipalityList) []*ripper.Plugin {
+	// cache the result for the same text
+	var lastText *ripper.TextData
+	var lastResult []Address
+	find := func(text *ripper.TextData) []Address {
+		if text != lastText {
+			lastText = text
+			lastResult = l.FindAddresses(text.GetRaw())
+		}
+		return lastResult
+	}
+	join := func(list []Address, fn func(a Address) string) string {
+		results := make([]string, len(list))
+		for i, a := range list {
+			results[i] = fn(a)
+		}
+		return strings.Join(results, " ")
+	}
+
+	return []*ripper.Plugin{
+		{
+			Title: "address",
+			Fn: func(text *ripper.TextData) string {
+				return join(find(text), func(a Address) string { return a.Text })
+			},
+		},
+		{
+			Title: "prefecture",
+			Fn: func(text *ripper.TextData) string {
+				return join(find(text), func(a Address) string { return a.Prefecture })
+			},
+		},
+		{
+			Title: "municipality",
+			Fn: func(text *ripper.TextData) string {
+				return join(find(text), func(a Address) string { return a.Municipality })
+			},
+		},
+		{
+			Title: "postal_code",
+			Fn: func(text *ripper.TextData) string {
+				return strings.Join(FindPostalCodes(text.GetRaw()), " ")
+			},
+		},
+	}
+}
+
+// prefectures are the names of 47 prefectures.
+var prefectures = []string{
+	"北海道", "青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県",
+	"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "東京都", "神奈川県",
+	"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県", "岐阜県",
+	"静岡県", "愛知県", "三重県", "滋賀県", "京都府", "大阪府", "兵庫県",
+	"奈良県", "和歌山県", "鳥取県", "島根県", "岡山県", "広島県", "山口県",
+	"徳島県", "香川県", "愛媛県", "高知県", "福岡県", "佐賀県", "長崎県",
+	"熊本県", "大分県", "宮崎県", "鹿児島県", "沖縄県",
+}
+
+// bundledMunicipalities are designated cities (政令指定都市) and special wards of Tokyo.
+var bundledMunicipalities = map[string][]string{
+	"北海道":  {"札幌市"},
+	"宮城県":  {"仙台市"},
+	"埼玉県":  {"さいたま市"},
+	"千葉県":  {"千葉市"},
+	"神奈川県": {"横浜市", "川崎市", "相模原市"},
+	"新潟県":  {"新潟市"},
+	"静岡県":  {"静岡市", "浜松市"},
+	"愛知県":  {"名古屋市"},
+	"京都府":  {"京都市"},
+	"大阪府":  {"大阪市", "堺市"},
+	"兵庫県":  {"神戸市"},
+	"岡山県":  {"岡山市"},
+	"広島県":  {"広島市"},
+	"福岡県":  {"北九州市", "福岡市"},
+	"熊本県":  {"熊本市"},
+	"東京都": {
+		"千代田区", "中央区", "港区", "新宿区", "文京区", "台東区", "墨田区", "江東区",
+		"品川区", "目黒区", "大田区", "世田谷区", "渋谷区", "中野区", "杉並区", "豊島区",
+		"北区", "荒川区", "板橋区", "練馬区", "足立区", "葛飾区", "江戸川区",
+	},
+}
@@ -0,0 +1,115 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindAddresses(t *testing.T) {
+	l := NewMunicipalityList()
+
+	tests := []struct {
+		text     string
+		expected []Address
+	}{
+		{
+			"〒105-0011 東京都港区芝公園4丁目2番8号",
+			[]Address{{Text: "東京都港区芝公園4丁目2番8号", Prefecture: "東京都", Municipality: "港区"}},
+		},
+		{
+			"京都府京都市中京区寺町488",
+			[]Address{{Text: "京都府京都市中京区寺町488", Prefecture: "京都府", Municipality: "京都市中京区"}},
+		},
+		{
+			"横浜市中区山下町1-1に行く",
+			[]Address{{Text: "横浜市中区山下町1-1", Prefecture: "神奈川県", Municipality: "横浜市中区"}},
+		},
+		{
+			"京都市に住む",
+			[]Address{{Text: "京都市", Prefecture: "京都府", Municipality: "京都市"}},
+		},
+		{
+			"東京都府中市宮西町2-24",
+			[]Address{{Text: "東京都府中市宮西町2-24", Prefecture: "東京都", Municipality: "府中市"}},
+		},
+		{
+			"栃木県宇都宮市旭1-1-5",
+			[]Address{{Text: "栃木県宇都宮市旭1-1-5", Prefecture: "栃木県", Municipality: "宇都宮市"}},
+		},
+		{
+			"千葉県市川市八幡1-1-1",
+			[]Address{{Text: "千葉県市川市八幡1-1-1", Prefecture: "千葉県", Municipality: "市川市"}},
+		},
+		{
+			"北海道虻田郡倶知安町北1条東3丁目",
+			[]Address{{Text: "北海道虻田郡倶知安町北1条東3丁目", Prefecture: "北海道", Municipality: "虻田郡倶知安町"}},
+		},
+		// municipalities without the prefecture are detected only for designated cities
+		{"府中市に住む", nil},
+		{"東京都では", nil},
+	}
+
+	for _, tt := range tests {
+		list := l.FindAddresses(tt.text)
+		if len(list) != len(tt.expected) {
+			t.Errorf("FindAddresses(%q) = %+v, want %+v", tt.text, list, tt.expected)
+			continue
+		}
+		for i, a := range list {
+			if a != tt.expected[i] {
+				t.Errorf("FindAddresses(%q)[%d] = %+v, want %+v", tt.text, i, a, tt.expected[i])
+			}
+		}
+	}
+}
+
+func TestMunicipalityListLoad(t *testing.T) {
+	l := NewMunicipalityList()
+	if err := l.Load(strings.NewReader("# comment\n\n東京都\t府中市\n")); err != nil {
+		t.Fatalf("Load() err = %v", err)
+	}
+
+	tests := []struct {
+		text         string
+		municipality string
+	}{
+		{"東京都府中市宮西町", "府中市"},
+		{"東京都港区芝公園", "港区"},
+		// not in the list
+		{"東京都調布市小島町", ""},
+	}
+	for _, tt := range tests {
+		list := l.FindAddresses(tt.text)
+		got := ""
+		if len(list) != 0 {
+			got = list[0].Municipality
+		}
+		if got != tt.municipality {
+			t.Errorf("FindAddresses(%q) municipality = %q, want %q", tt.text, got, tt.municipality)
+		}
+	}
+
+	if err := l.Load(strings.NewReader("府中市\n")); err == nil {
+		t.Errorf("Load() should return error for invalid format")
+	}
+}
+
+func TestFindPostalCodes(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"〒105-0011", []string{"105-0011"}},
+		{"〒１０５００１１", []string{"105-0011"}},
+		{"105-0011", []string{"105-0011"}},
+		{"1050011", nil},
+		{"090-1234-5678", nil},
+	}
+
+	for _, tt := range tests {
+		got := FindPostalCodes(tt.text)
+		if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("FindPostalCodes(%q) = %v, want %v", tt.text, got, tt.expected)
+		}
+	}
+}
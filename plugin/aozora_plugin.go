@@ -0,0 +1,22 @@
+package plugin
+
+import (
+	"strings"
+
+	"github.com/evalphobia/go-jp-text-ripper/prefilter"
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// AozoraRubyPlugin outputs ruby readings of Aozora Bunko text, as 'base:reading' separated by space.
+// The readings are extracted from raw text, so use this plugin with prefilter.Aozora.
+var AozoraRubyPlugin = &ripper.Plugin{
+	Title: "aozora_ruby",
+	Fn: func(text *ripper.TextData) string {
+		list := prefilter.ExtractAozoraRuby(text.GetRaw())
+		results := make([]string, len(list))
+		for i, v := range list {
+			results[i] = v.Base + ":" + v.Reading
+		}
+		return strings.Join(results, " ")
+	},
+}
@@ -0,0 +1,522 @@
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/evalphobia/go-jp-text-ripper/prefilter"
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+const defaultDateTimeSeparator = " "
+
+// layouts to parse the reference date.
+var referenceDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	"2006-1-2",
+	"2006/1/2",
+	"20060102",
+}
+
+// DateTimeConfig contains options for DateTimePlugin.
+type DateTimeConfig struct {
+	// base date to resolve relative expressions (e.g. '昨日', '来週の月曜'). default is current time.
+	ReferenceDate time.Time
+	// column name of the reference date of each row, it must exist in the header.
+	// ReferenceDate is used when the column value is empty or invalid.
+	ReferenceColumn string
+	// separator of the values
+	Separator string
+}
+
+func (c *DateTimeConfig) init() {
+	if c.ReferenceDate.IsZero() {
+		c.ReferenceDate = time.Now()
+	}
+	if c.Separator == "" {
+		c.Separator = defaultDateTimeSeparator
+	}
+}
+
+// NewDateTimePlugin returns the plugin to output date and time expressions in raw text as ISO-8601 format.
+// (e.g. '2024年3月1日' -> '2024-03-01', '令和6年' -> '2024', '来週の月曜 午後3時' -> '2024-03-11T15:00')
+func NewDateTimePlugin(c DateTimeConfig) *ripper.Plugin {
+	c.init()
+	var columns []string
+	if c.ReferenceColumn != "" {
+		columns = append(columns, c.ReferenceColumn)
+	}
+	return &ripper.Plugin{
+		Title:   "datetimes",
+		Columns: columns,
+		Fn: func(text *ripper.TextData) string {
+			ref := c.ReferenceDate
+			if c.ReferenceColumn != "" {
+				if t, ok := ParseReferenceDate(text.GetColumn(c.ReferenceColumn)); ok {
+					ref = t
+				}
+			}
+
+			list := FindDateTimes(text.GetRaw(), ref)
+			results := make([]string, len(list))
+			for i, v := range list {
+				results[i] = v.Value
+			}
+			return strings.Join(results, c.Separator)
+		},
+	}
+}
+
+// ParseReferenceDate parses the date string (e.g. '2024-03-01', '2024/03/01 10:00:00').
+func ParseReferenceDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range referenceDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// DateTimeExpr is a date and time expression in the text.
+type DateTimeExpr struct {
+	Text string
+	// ISO-8601 format (e.g. '2024', '2024-03', '2024-03-01', '2024-W10', '15:00', '2024-03-01T15:00')
+	Value string
+}
+
+// precision of the date and time expression.
+const (
+	dateTimeYear = iota
+	dateTimeMonth
+	dateTimeWeek
+	dateTimeDay
+	dateTimeTime
+)
+
+type dateTimeMatch struct {
+	start     int
+	end       int
+	t         time.Time
+	precision int
+	// time has seconds or not
+	hasSecond bool
+	// time is '24時' (the end of the day)
+	isEndOfDay bool
+	// time is valid only after the date (e.g. '一時' is a part of '一時停止')
+	needsDate bool
+}
+
+func (m dateTimeMatch) format() string {
+	switch m.precision {
+	case dateTimeYear:
+		return m.t.Format("2006")
+	case dateTimeMonth:
+		return m.t.Format("2006-01")
+	case dateTimeWeek:
+		year, week := m.t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case dateTimeDay:
+		return m.t.Format("2006-01-02")
+	}
+	if m.isEndOfDay {
+		return "24:00"
+	}
+	if m.hasSecond {
+		return m.t.Format("15:04:05")
+	}
+	return m.t.Format("15:04")
+}
+
+// combine returns the date and time (e.g. '2024-03-01T15:00').
+// '24時' is converted into '00:00' of the next day.
+func (m dateTimeMatch) combine(tm dateTimeMatch) string {
+	if tm.isEndOfDay {
+		next := m
+		next.t = m.t.AddDate(0, 0, 1)
+		return next.format() + "T00:00"
+	}
+	return m.format() + "T" + tm.format()
+}
+
+// dateTimeRule is a pattern of the date and time expression.
+// fn returns false when the matched text is not valid.
+type dateTimeRule struct {
+	re *regexp.Regexp
+	fn func(sub []string, ref time.Time) (dateTimeMatch, bool)
+}
+
+const (
+	reDateNumber = `([0-9〇一二三四五六七八九十]{1,3})`
+	reDateYear   = `([0-9]{4}|[〇一二三四五六七八九]{4})`
+	// day of the month, or the end of the month (末)
+	reDateDay = `([0-9〇一二三四五六七八九十]{1,3}日|末)`
+)
+
+var eraStartYears = map[string]int{
+	"明治": 1868, "M": 1868,
+	"大正": 1912, "T": 1912,
+	"昭和": 1926, "S": 1926,
+	"平成": 1989, "H": 1989,
+	"令和": 2019, "R": 2019,
+}
+
+var relativeDays = map[string]int{
+	"一昨日": -2, "おととい": -2,
+	"昨日": -1,
+	"今日": 0, "本日": 0,
+	"明日":  1,
+	"明後日": 2, "あさって": 2,
+}
+
+var relativeWeeks = map[string]int{
+	"先週": -1, "今週": 0, "来週": 1, "再来週": 2,
+}
+
+var relativeMonths = map[string]int{
+	"先月": -1, "今月": 0, "来月": 1, "再来月": 2,
+}
+
+var relativeYears = map[string]int{
+	"一昨年": -2, "おととし": -2,
+	"昨年": -1, "去年": -1,
+	"今年":  0,
+	"来年":  1,
+	"再来年": 2,
+}
+
+var weekdays = map[string]time.Weekday{
+	"月": time.Monday, "火": time.Tuesday, "水": time.Wednesday, "木": time.Thursday,
+	"金": time.Friday, "土": time.Saturday, "日": time.Sunday,
+}
+
+var dateTimeRules = []dateTimeRule{
+	// 2024年3月1日, 2024年3月末, 2024年3月, 2024年
+	{
+		re: regexp.MustCompile(reDateYear + `年(?:` + reDateNumber + `月` + reDateDay + `?)?`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			return newDateMatch(parseDateNumber(sub[1]), sub[2], sub[3])
+		},
+	},
+	// 2024/3/1, 2024-03-01, 2024.3.1
+	{
+		re: regexp.MustCompile(`([0-9]{4})([/\-.])([0-9]{1,2})([/\-.])([0-9]{1,2})`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			if sub[2] != sub[4] {
+				return dateTimeMatch{}, false
+			}
+			return newDateMatch(parseDateNumber(sub[1]), sub[3], sub[5]+"日")
+		},
+	},
+	// 令和6年3月1日, 平成元年
+	{
+		re: regexp.MustCompile(`(明治|大正|昭和|平成|令和)(元|` + reDateNumber + `)年(?:` + reDateNumber + `月` + reDateDay + `?)?`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			return newDateMatch(eraYear(sub[1], sub[2]), sub[4], sub[5])
+		},
+	},
+	// R6.3.1, H31/4/30
+	{
+		re: regexp.MustCompile(`\b([MTSHR])([0-9]{1,2})([./\-])([0-9]{1,2})([./\-])([0-9]{1,2})\b`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			if sub[5] != sub[3] {
+				return dateTimeMatch{}, false
+			}
+			return newDateMatch(eraYear(sub[1], sub[2]), sub[4], sub[6]+"日")
+		},
+	},
+	// 3月1日, 3月末, 3月 (the year of the reference date)
+	{
+		re: regexp.MustCompile(reDateNumber + `月` + reDateDay + `?`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			return newDateMatch(ref.Year(), sub[1], sub[2])
+		},
+	},
+	// 3日前, 2週間後, 3か月前, 1年後
+	{
+		re: regexp.MustCompile(reDateNumber + `(日|週間|週|か月|ヶ月|ケ月|カ月|ヵ月|年)(前|後)`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			n := parseDateNumber(sub[1])
+			if n < 0 {
+				return dateTimeMatch{}, false
+			}
+			if sub[3] == "前" {
+				n = -n
+			}
+			switch sub[2] {
+			case "日":
+				return dateTimeMatch{t: ref.AddDate(0, 0, n), precision: dateTimeDay}, true
+			case "週間", "週":
+				return dateTimeMatch{t: ref.AddDate(0, 0, 7*n), precision: dateTimeDay}, true
+			case "年":
+				return dateTimeMatch{t: ref.AddDate(n, 0, 0), precision: dateTimeYear}, true
+			}
+			return dateTimeMatch{t: addMonths(ref, n), precision: dateTimeMonth}, true
+		},
+	},
+	// 来週の月曜, 先週金曜日, 来週
+	{
+		re: regexp.MustCompile(`(先週|今週|再来週|来週)(?:の?([月火水木金土日])曜日?)?`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			t := ref.AddDate(0, 0, 7*relativeWeeks[sub[1]])
+			if sub[2] == "" {
+				return dateTimeMatch{t: t, precision: dateTimeWeek}, true
+			}
+			// weeks start on Monday
+			offset := (int(weekdays[sub[2]]) + 6) % 7
+			current := (int(t.Weekday()) + 6) % 7
+			return dateTimeMatch{t: t.AddDate(0, 0, offset-current), precision: dateTimeDay}, true
+		},
+	},
+	// 昨日, 今日, 明日
+	{
+		re: regexp.MustCompile(`一昨日|おととい|昨日|今日|本日|明後日|あさって|明日`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			return dateTimeMatch{t: ref.AddDate(0, 0, relativeDays[sub[0]]), precision: dateTimeDay}, true
+		},
+	},
+	// 先月, 今月, 来月, 来月末
+	{
+		re: regexp.MustCompile(`(先月|今月|再来月|来月)(末)?`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			t := addMonths(ref, relativeMonths[sub[1]])
+			if sub[2] != "" {
+				return dateTimeMatch{t: endOfMonth(t), precision: dateTimeDay}, true
+			}
+			return dateTimeMatch{t: t, precision: dateTimeMonth}, true
+		},
+	},
+	// 去年, 今年, 来年, 今年末
+	{
+		re: regexp.MustCompile(`(一昨年|おととし|昨年|去年|今年|再来年|来年)(末)?`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			t := ref.AddDate(relativeYears[sub[1]], 0, 0)
+			if sub[2] != "" {
+				return dateTimeMatch{t: time.Date(t.Year(), 12, 31, 0, 0, 0, 0, t.Location()), precision: dateTimeDay}, true
+			}
+			return dateTimeMatch{t: t, precision: dateTimeYear}, true
+		},
+	},
+	// 午後3時, 10時半, 15時30分
+	{
+		re: regexp.MustCompile(`(午前|午後)?` + reDateNumber + `時(?:(半)|` + reDateNumber + `分)?(間)?`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			if sub[5] != "" {
+				// duration (e.g. '3時間')
+				return dateTimeMatch{}, false
+			}
+			hour := parseDateNumber(sub[2])
+			if sub[1] != "" && hour > 12 {
+				return dateTimeMatch{}, false
+			}
+			if sub[1] == "午後" && hour < 12 {
+				hour += 12
+			}
+			minute := 0
+			switch {
+			case sub[3] != "":
+				minute = 30
+			case sub[4] != "":
+				minute = parseDateNumber(sub[4])
+			}
+			m, ok := newTimeMatch(hour, minute, 0, false)
+			// one kanji numeral needs 午前/午後, 分, 半 or the date (e.g. '一時的', '一時停止')
+			m.needsDate = sub[1] == "" && sub[3] == "" && sub[4] == "" && utf8.RuneCountInString(sub[2]) == 1 && sub[2][0] >= utf8.RuneSelf
+			return m, ok
+		},
+	},
+	// 15:30, 9:05:10
+	{
+		re: regexp.MustCompile(`\b([0-9]{1,2}):([0-9]{2})(?::([0-9]{2}))?\b`),
+		fn: func(sub []string, ref time.Time) (dateTimeMatch, bool) {
+			second := 0
+			if sub[3] != "" {
+				second = parseDateNumber(sub[3])
+			}
+			return newTimeMatch(parseDateNumber(sub[1]), parseDateNumber(sub[2]), second, sub[3] != "")
+		},
+	},
+}
+
+// reDateTimeJoint is the text between the date and the time to combine them (e.g. '3月1日の15時').
+var reDateTimeJoint = regexp.MustCompile(`^[\s　の、,]*$`)
+
+// FindDateTimes returns date and time expressions in the text.
+// Relative expressions (e.g. '昨日', '3日前', '来週の月曜') are resolved by the reference date.
+func FindDateTimes(s string, ref time.Time) []DateTimeExpr {
+	s = normalizeDateText(s)
+
+	var matches []dateTimeMatch
+	for _, rule := range dateTimeRules {
+		for _, idx := range rule.re.FindAllStringSubmatchIndex(s, -1) {
+			sub := make([]string, len(idx)/2)
+			for i := range sub {
+				if idx[2*i] >= 0 {
+					sub[i] = s[idx[2*i]:idx[2*i+1]]
+				}
+			}
+			m, ok := rule.fn(sub, ref)
+			if !ok {
+				continue
+			}
+			m.start, m.end = idx[0], idx[1]
+			matches = append(matches, m)
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	// use the longest match from the first
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].start != matches[j].start {
+			return matches[i].start < matches[j].start
+		}
+		return matches[i].end > matches[j].end
+	})
+	var list []DateTimeExpr
+	last := 0
+	for i := 0; i < len(matches); i++ {
+		m := matches[i]
+		if m.start < last || m.needsDate {
+			continue
+		}
+		last = m.end
+		value := m.format()
+
+		// combine the date and the next time
+		if m.precision == dateTimeDay {
+			if next, ok := nextMatch(matches, i, last); ok && next.precision == dateTimeTime && reDateTimeJoint.MatchString(s[last:next.start]) {
+				value = m.combine(next)
+				last = next.end
+			}
+		}
+		list = append(list, DateTimeExpr{
+			Text:  s[m.start:last],
+			Value: value,
+		})
+	}
+	return list
+}
+
+// nextMatch returns the next match which starts after the position.
+func nextMatch(matches []dateTimeMatch, i, pos int) (dateTimeMatch, bool) {
+	for _, m := range matches[i+1:] {
+		if m.start >= pos {
+			return m, true
+		}
+	}
+	return dateTimeMatch{}, false
+}
+
+func newDateMatch(year int, month, day string) (dateTimeMatch, bool) {
+	if year <= 0 {
+		return dateTimeMatch{}, false
+	}
+	if month == "" {
+		return dateTimeMatch{t: time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), precision: dateTimeYear}, true
+	}
+
+	m := parseDateNumber(month)
+	if m < 1 || m > 12 {
+		return dateTimeMatch{}, false
+	}
+	if day == "" {
+		return dateTimeMatch{t: time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC), precision: dateTimeMonth}, true
+	}
+
+	if day == "末" {
+		return dateTimeMatch{t: endOfMonth(time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC)), precision: dateTimeDay}, true
+	}
+	d := parseDateNumber(strings.TrimSuffix(day, "日"))
+	t := time.Date(year, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	if d < 1 || t.Day() != d {
+		// invalid date (e.g. 2月30日)
+		return dateTimeMatch{}, false
+	}
+	return dateTimeMatch{t: t, precision: dateTimeDay}, true
+}
+
+func newTimeMatch(hour, minute, second int, hasSecond bool) (dateTimeMatch, bool) {
+	if hour < 0 || hour > 24 || minute < 0 || minute > 59 || second < 0 || second > 59 {
+		return dateTimeMatch{}, false
+	}
+	if hour == 24 && (minute != 0 || second != 0) {
+		return dateTimeMatch{}, false
+	}
+	return dateTimeMatch{
+		t:          time.Date(0, 1, 1, hour, minute, second, 0, time.UTC),
+		precision:  dateTimeTime,
+		hasSecond:  hasSecond,
+		isEndOfDay: hour == 24,
+	}, true
+}
+
+// eraYear converts japanese era year into the western year (e.g. '令和6' -> 2024).
+func eraYear(era, year string) int {
+	start, ok := eraStartYears[era]
+	if !ok {
+		return -1
+	}
+	if year == "元" {
+		return start
+	}
+	n := parseDateNumber(year)
+	if n < 1 {
+		return -1
+	}
+	return start + n - 1
+}
+
+// addMonths adds months to the first day of the month, not to overflow into the next month (e.g. 1/31 + 1 month).
+func addMonths(t time.Time, n int) time.Time {
+	return time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+}
+
+// endOfMonth returns the last day of the month.
+func endOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location())
+}
+
+// parseDateNumber parses arabic numerals or kanji numerals, and returns -1 when it's invalid.
+func parseDateNumber(s string) int {
+	v, ok := prefilter.ParseNumber(s)
+	if !ok || !v.IsInt() || !v.Num().IsInt64() {
+		return -1
+	}
+	return int(v.Num().Int64())
+}
+
+// normalizeDateText converts full-width digits and symbols into half-width.
+func normalizeDateText(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case '０' <= r && r <= '９':
+			return r - '０' + '0'
+		case 'Ａ' <= r && r <= 'Ｚ':
+			return r - 'Ａ' + 'A'
+		}
+		switch r {
+		case '／':
+			return '/'
+		case '－':
+			return '-'
+		case '．':
+			return '.'
+		case '：':
+			return ':'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,81 @@
+package plugin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindDateTimes(t *testing.T) {
+	// Friday
+	ref := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"2024年3月1日に", []string{"2024-03-01"}},
+		{"２０２４／３／１", []string{"2024-03-01"}},
+		{"令和6年3月1日", []string{"2024-03-01"}},
+		{"平成元年", []string{"1989"}},
+		{"R6.3.1", []string{"2024-03-01"}},
+		{"5月5日", []string{"2024-05-05"}},
+		{"2月30日", nil},
+		{"3日前", []string{"2024-02-27"}},
+		{"2週間後", []string{"2024-03-15"}},
+		{"来週の月曜", []string{"2024-03-04"}},
+		{"昨日と明日", []string{"2024-02-29", "2024-03-02"}},
+		{"来月", []string{"2024-04"}},
+		{"来月末", []string{"2024-04-30"}},
+		{"2月末", []string{"2024-02-29"}},
+		{"2023年2月末", []string{"2023-02-28"}},
+		{"今年末", []string{"2024-12-31"}},
+		{"午後3時", []string{"15:00"}},
+		{"10時半", []string{"10:30"}},
+		{"9:05:10", []string{"09:05:10"}},
+		{"3時間", nil},
+		{"24時", []string{"24:00"}},
+		{"3月1日の24時", []string{"2024-03-02T00:00"}},
+		{"3月31日 24時", []string{"2024-04-01T00:00"}},
+		{"3月1日の15時30分", []string{"2024-03-01T15:30"}},
+		// one kanji numeral before 時 is a part of a word
+		{"一時的に停止", nil},
+		{"一時停止", nil},
+		{"午後一時", []string{"13:00"}},
+		{"三時半", []string{"03:30"}},
+		{"三時十分", []string{"03:10"}},
+		{"十一時", []string{"11:00"}},
+		{"3月1日の三時", []string{"2024-03-01T03:00"}},
+	}
+
+	for _, tt := range tests {
+		list := FindDateTimes(tt.text, ref)
+		if len(list) != len(tt.expected) {
+			t.Errorf("FindDateTimes(%q) = %+v, want %v", tt.text, list, tt.expected)
+			continue
+		}
+		for i, v := range list {
+			if v.Value != tt.expected[i] {
+				t.Errorf("FindDateTimes(%q)[%d] = %q, want %q", tt.text, i, v.Value, tt.expected[i])
+			}
+		}
+	}
+}
+
+func TestParseReferenceDate(t *testing.T) {
+	tests := []struct {
+		text string
+		ok   bool
+	}{
+		{"2024-03-01", true},
+		{"2024/03/01 10:00:00", true},
+		{"20240301", true},
+		{"", false},
+		{"yesterday", false},
+	}
+
+	for _, tt := range tests {
+		if _, ok := ParseReferenceDate(tt.text); ok != tt.ok {
+			t.Errorf("ParseReferenceDate(%q) ok = %v, want %v", tt.text, ok, tt.ok)
+		}
+	}
+}
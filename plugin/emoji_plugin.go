@@ -0,0 +1,120 @@
+package plugin
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evalphobia/go-jp-text-ripper/prefilter"
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// EmojiPlugins are the plugins to output emoji and kaomoji in raw text.
+var EmojiPlugins = []*ripper.Plugin{
+	EmojiCountPlugin,
+	EmojiDistinctPlugin,
+	KaomojiCountPlugin,
+	KaomojiPlugin,
+}
+
+// EmojiCountPlugin calculates emoji count from raw text.
+// ZWJ sequences and skin tones are counted as one emoji (e.g. '👨‍👩‍👧', '👍🏽').
+var EmojiCountPlugin = &ripper.Plugin{
+	Title: "emoji_count",
+	Fn: func(text *ripper.TextData) string {
+		return strconv.Itoa(len(prefilter.FindEmoji(text.GetRaw())))
+	},
+}
+
+// EmojiDistinctPlugin outputs distinct emoji in raw text separated by space.
+var EmojiDistinctPlugin = &ripper.Plugin{
+	Title: "emoji_distinct",
+	Fn: func(text *ripper.TextData) string {
+		return strings.Join(distinct(prefilter.FindEmoji(text.GetRaw())), " ")
+	},
+}
+
+// KaomojiCountPlugin calculates kaomoji count from raw text.
+var KaomojiCountPlugin = &ripper.Plugin{
+	Title: "kaomoji_count",
+	Fn: func(text *ripper.TextData) string {
+		return strconv.Itoa(len(FindKaomoji(text.GetRaw())))
+	},
+}
+
+// KaomojiPlugin outputs distinct kaomoji in raw text separated by space.
+var KaomojiPlugin = &ripper.Plugin{
+	Title: "kaomoji",
+	Fn: func(text *ripper.TextData) string {
+		return strings.Join(distinct(FindKaomoji(text.GetRaw())), " ")
+	},
+}
+
+// kaomojiPatterns are the patterns of kaomoji.
+// the last one is the face in parentheses with arms, and the face is checked by isKaomojiFace.
+var kaomojiPatterns = []string{
+	`¯\\_\(ツ\)_/¯`,
+	`[mｍ][(（][_＿][ 　]?[_＿][)）][mｍ]`,
+	`\b(?:orz|OTL)\b`,
+	`\^[_oｏ]?\^`,
+	`＾[_＿oｏ]?＾`,
+	`[ヽ＼\\٩ლσ┗⊂(（]?[(（][^()（）\s]{1,20}[)）][ノﾉ／/۶ლσ┛⊃)）]?`,
+}
+
+var reKaomoji = regexp.MustCompile(strings.Join(kaomojiPatterns, "|"))
+
+// kaomojiFaceParts are the characters used as eyes and mouth of kaomoji.
+const kaomojiFaceParts = "´`｀・ω∀▽Д^＾;；_＿≧≦ﾟ゜◕‿◡⌒╹◠ツεдoO*＊><＞＜TＴ-ー‐'゚∇○◎●皿益艸ノ"
+
+// FindKaomoji returns kaomoji in the text (e.g. '(´・ω・`)', 'ヽ(・∀・)ﾉ', 'm(_ _)m', 'orz').
+func FindKaomoji(s string) []string {
+	var list []string
+	for _, m := range reKaomoji.FindAllString(s, -1) {
+		if strings.ContainsAny(m, "(（") && !strings.HasPrefix(m, "¯") && !isKaomojiFace(m) {
+			continue
+		}
+		list = append(list, m)
+	}
+	return list
+}
+
+// isKaomojiFace checks the text in parentheses is a face or not.
+// letters and digits other than the face parts are not allowed (e.g. '(笑)', '(1)', '(abc)').
+func isKaomojiFace(s string) bool {
+	start := strings.IndexAny(s, "(（")
+	end := strings.LastIndexAny(s, ")）")
+	if start < 0 || end <= start {
+		return false
+	}
+	_, size := utf8.DecodeRuneInString(s[start:])
+	face := s[start+size : end]
+	if len([]rune(face)) < 2 {
+		return false
+	}
+
+	hasPart := false
+	for _, r := range face {
+		isPart := strings.ContainsRune(kaomojiFaceParts, r)
+		if !isPart && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+		hasPart = hasPart || isPart
+	}
+	return hasPart
+}
+
+// distinct returns unique values in order of appearance.
+func distinct(list []string) []string {
+	m := make(map[string]struct{}, len(list))
+	results := make([]string, 0, len(list))
+	for _, v := range list {
+		if _, ok := m[v]; ok {
+			continue
+		}
+		m[v] = struct{}{}
+		results = append(results, v)
+	}
+	return results
+}
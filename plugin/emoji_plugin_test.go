@@ -0,0 +1,29 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindKaomoji(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"おはよう(´・ω・`)", []string{"(´・ω・`)"}},
+		{"やったヽ(・∀・)ﾉ", []string{"ヽ(・∀・)ﾉ"}},
+		{"失敗したorz", []string{"orz"}},
+		{"sorza", nil},
+		{"楽しい^^", []string{"^^"}},
+		// parentheses without face parts are not kaomoji
+		{"面白い(笑)", nil},
+		{"手順(1)", nil},
+		{"関数(abc)", nil},
+	}
+
+	for _, tt := range tests {
+		if got := FindKaomoji(tt.text); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("FindKaomoji(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
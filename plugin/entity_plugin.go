@@ -0,0 +1,70 @@
+package plugin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/evalphobia/go-jp-text-ripper/prefilter"
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// NewEntityPlugins returns the plugins to output the count of url, email, hashtag and mention,
+// and the lists of hashtags, mentions and domains separated by space.
+// The entities are extracted from raw text, so these can be used with prefilter.Entity.
+func NewEntityPlugins() []*ripper.Plugin {
+	// cache the result for the same text
+	var lastText *ripper.TextData
+	var lastResult prefilter.Entities
+	extract := func(text *ripper.TextData) prefilter.Entities {
+		if text != lastText {
+			lastText = text
+			lastResult = prefilter.ExtractEntities(text.GetRaw())
+		}
+		return lastResult
+	}
+
+	return []*ripper.Plugin{
+		{
+			Title: "url_count",
+			Fn: func(text *ripper.TextData) string {
+				return strconv.Itoa(len(extract(text).URLs))
+			},
+		},
+		{
+			Title: "email_count",
+			Fn: func(text *ripper.TextData) string {
+				return strconv.Itoa(len(extract(text).Emails))
+			},
+		},
+		{
+			Title: "hashtag_count",
+			Fn: func(text *ripper.TextData) string {
+				return strconv.Itoa(len(extract(text).Hashtags))
+			},
+		},
+		{
+			Title: "mention_count",
+			Fn: func(text *ripper.TextData) string {
+				return strconv.Itoa(len(extract(text).Mentions))
+			},
+		},
+		{
+			Title: "hashtags",
+			Fn: func(text *ripper.TextData) string {
+				return strings.Join(distinct(extract(text).Hashtags), " ")
+			},
+		},
+		{
+			Title: "mentions",
+			Fn: func(text *ripper.TextData) string {
+				return strings.Join(distinct(extract(text).Mentions), " ")
+			},
+		},
+		{
+			Title: "domains",
+			Fn: func(text *ripper.TextData) string {
+				return strings.Join(distinct(extract(text).Domains()), " ")
+			},
+		},
+	}
+}
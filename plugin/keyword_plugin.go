@@ -0,0 +1,229 @@
+package plugin
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+	"github.com/evalphobia/go-jp-text-ripper/tokenizer"
+)
+
+// keyword extraction methods.
+const (
+	KeywordTextRank = "textrank"
+	KeywordRAKE     = "rake"
+)
+
+const (
+	defaultKeywordTopNumber  = 5
+	defaultKeywordWindowSize = 2
+	defaultKeywordSeparator  = ","
+
+	textRankDamping    = 0.85
+	textRankIterations = 30
+	textRankTolerance  = 0.0001
+)
+
+// KeywordConfig contains options for KeywordPlugin.
+type KeywordConfig struct {
+	// keyword extraction method (textrank, rake)
+	Method string
+	// number of keywords per row
+	TopNumber int
+	// co-occurrence window size for textrank
+	WindowSize int
+	// separator of the keywords
+	Separator string
+	// word => idf from the corpus, the score is weighted by idf if it's set.
+	// use ripper.GetCorpusIDF to get idf in the first pass.
+	IDF map[string]float64
+}
+
+func (c *KeywordConfig) init() {
+	if c.Method == "" {
+		c.Method = KeywordTextRank
+	}
+	if c.TopNumber <= 0 {
+		c.TopNumber = defaultKeywordTopNumber
+	}
+	if c.WindowSize <= 0 {
+		c.WindowSize = defaultKeywordWindowSize
+	}
+	if c.Separator == "" {
+		c.Separator = defaultKeywordSeparator
+	}
+}
+
+// NewKeywordPlugin returns the plugin to extract top keywords of each row by TextRank or RAKE.
+func NewKeywordPlugin(c KeywordConfig) *ripper.Plugin {
+	c.init()
+
+	maxIDF := 0.0
+	for _, v := range c.IDF {
+		maxIDF = math.Max(maxIDF, v)
+	}
+	weight := func(word string) float64 {
+		if c.IDF == nil {
+			return 1
+		}
+		if v, ok := c.IDF[word]; ok {
+			return v
+		}
+		// unseen word in the corpus is the rarest
+		return maxIDF
+	}
+
+	return &ripper.Plugin{
+		Title: "keywords",
+		Fn: func(text *ripper.TextData) string {
+			var list []keywordScore
+			switch c.Method {
+			case KeywordRAKE:
+				list = extractByRAKE(text.GetWords(), text.GetWordKeys(), weight)
+			default:
+				list = extractByTextRank(text.GetWordKeys(), c.WindowSize, weight)
+			}
+			return strings.Join(topKeywords(list, c.TopNumber), c.Separator)
+		},
+	}
+}
+
+type keywordScore struct {
+	word  string
+	score float64
+}
+
+// topKeywords returns the keywords from the highest score.
+func topKeywords(list []keywordScore, n int) []string {
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].score > list[j].score
+	})
+	if len(list) > n {
+		list = list[:n]
+	}
+
+	words := make([]string, len(list))
+	for i, v := range list {
+		words[i] = v.word
+	}
+	return words
+}
+
+// extractByTextRank calculates TextRank score over the co-occurrence graph of the words.
+func extractByTextRank(words []string, windowSize int, weight func(string) float64) []keywordScore {
+	index := make(map[string]int)
+	var nodes []string
+	for _, w := range words {
+		if _, ok := index[w]; !ok {
+			index[w] = len(nodes)
+			nodes = append(nodes, w)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	// undirected graph weighted by co-occurrence count in the window
+	edges := make([]map[int]float64, len(nodes))
+	for i := range edges {
+		edges[i] = make(map[int]float64)
+	}
+	for i, w := range words {
+		for j := i + 1; j < len(words) && j <= i+windowSize; j++ {
+			a, b := index[w], index[words[j]]
+			if a == b {
+				continue
+			}
+			edges[a][b]++
+			edges[b][a]++
+		}
+	}
+	outSum := make([]float64, len(nodes))
+	for i, e := range edges {
+		for _, v := range e {
+			outSum[i] += v
+		}
+	}
+
+	scores := make([]float64, len(nodes))
+	for i := range scores {
+		scores[i] = 1
+	}
+	for iter := 0; iter < textRankIterations; iter++ {
+		diff := 0.0
+		next := make([]float64, len(nodes))
+		for i, e := range edges {
+			sum := 0.0
+			for j, v := range e {
+				sum += v / outSum[j] * scores[j]
+			}
+			next[i] = (1 - textRankDamping) + textRankDamping*sum
+			diff += math.Abs(next[i] - scores[i])
+		}
+		scores = next
+		if diff < textRankTolerance {
+			break
+		}
+	}
+
+	list := make([]keywordScore, len(nodes))
+	for i, w := range nodes {
+		list[i] = keywordScore{
+			word:  w,
+			score: scores[i] * weight(w),
+		}
+	}
+	return list
+}
+
+// extractByRAKE calculates RAKE score of the phrases.
+// the phrase is consecutive words, which is split by non-words (e.g. particles, symbols and stopwords).
+// words are the keys of the tokens to match with idf.
+func extractByRAKE(tokens *tokenizer.TokenList, words []string, weight func(string) float64) []keywordScore {
+
+	var phrases [][]string
+	var phrase []string
+	lastEnd := -1
+	for i, t := range tokens.List {
+		if t.Start != lastEnd && len(phrase) != 0 {
+			phrases = append(phrases, phrase)
+			phrase = nil
+		}
+		phrase = append(phrase, words[i])
+		lastEnd = t.End
+	}
+	if len(phrase) != 0 {
+		phrases = append(phrases, phrase)
+	}
+
+	freq := make(map[string]float64)
+	degree := make(map[string]float64)
+	for _, p := range phrases {
+		for _, w := range p {
+			freq[w]++
+			degree[w] += float64(len(p))
+		}
+	}
+
+	seen := make(map[string]bool)
+	var list []keywordScore
+	for _, p := range phrases {
+		// phrase of Japanese words are joined without space
+		key := strings.Join(p, "")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		score := 0.0
+		for _, w := range p {
+			score += degree[w] / freq[w] * weight(w)
+		}
+		list = append(list, keywordScore{
+			word:  key,
+			score: score,
+		})
+	}
+	return list
+}
@@ -0,0 +1,67 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/evalphobia/go-jp-text-ripper/tokenizer"
+)
+
+func noWeight(string) float64 { return 1 }
+
+func TestExtractByTextRank(t *testing.T) {
+	tests := []struct {
+		words      []string
+		windowSize int
+		expected   []string
+	}{
+		// the center of the star is the highest
+		{[]string{"a", "b", "a", "c", "a", "d"}, 1, []string{"a", "b", "c", "d"}},
+		// the middle of the chain is the highest
+		{[]string{"x", "y", "z"}, 1, []string{"y", "x", "z"}},
+		// all of the words are connected in the window
+		{[]string{"x", "y", "z"}, 2, []string{"x", "y", "z"}},
+		{nil, 2, []string{}},
+	}
+
+	for _, tt := range tests {
+		list := extractByTextRank(tt.words, tt.windowSize, noWeight)
+		if got := topKeywords(list, 10); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("extractByTextRank(%v, %d) = %v, want %v", tt.words, tt.windowSize, got, tt.expected)
+		}
+	}
+
+	// the score is weighted
+	weight := func(w string) float64 {
+		if w == "z" {
+			return 10
+		}
+		return 1
+	}
+	list := extractByTextRank([]string{"x", "y", "z"}, 1, weight)
+	if got := topKeywords(list, 1); !reflect.DeepEqual(got, []string{"z"}) {
+		t.Errorf("extractByTextRank() with weight = %v, want [z]", got)
+	}
+}
+
+func TestExtractByRAKE(t *testing.T) {
+	tok := tokenizer.New(tokenizer.Config{WordPosList: []string{"名詞"}})
+
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		// consecutive nouns are a phrase
+		{"人工知能の研究と人工知能の応用", []string{"人工知能", "研究", "応用"}},
+		{"研究", []string{"研究"}},
+		{"", []string{}},
+	}
+
+	for _, tt := range tests {
+		words, _ := tok.Tokenize(tt.text)
+		list := extractByRAKE(words, tok.GetTokenKeys(words), noWeight)
+		if got := topKeywords(list, 10); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("extractByRAKE(%q) = %v, want %v", tt.text, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package plugin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/evalphobia/go-jp-text-ripper/prefilter"
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// NumberPlugins are the plugins to output number expressions in raw text.
+var NumberPlugins = []*ripper.Plugin{
+	NumberExprCountPlugin,
+	NumberValuesPlugin,
+}
+
+// NumberExprCountPlugin calculates number expression count from raw text.
+// kanji numerals and arabic numerals are parsed (e.g. '三千五百', '3,500', '3.5千').
+var NumberExprCountPlugin = &ripper.Plugin{
+	Title: "number_expr_count",
+	Fn: func(text *ripper.TextData) string {
+		return strconv.Itoa(len(prefilter.FindNumbers(text.GetRaw())))
+	},
+}
+
+// NumberValuesPlugin outputs the values of number expressions in raw text as arabic numerals separated by space.
+// (e.g. '三千五百円と1億2千万人' -> '3500 120000000')
+var NumberValuesPlugin = &ripper.Plugin{
+	Title: "numbers",
+	Fn: func(text *ripper.TextData) string {
+		list := prefilter.FindNumbers(text.GetRaw())
+		results := make([]string, len(list))
+		for i, n := range list {
+			results[i] = n.FormatValue()
+		}
+		return strings.Join(results, " ")
+	},
+}
@@ -0,0 +1,124 @@
+package plugin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/evalphobia/go-jp-text-ripper/prefilter"
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+	"github.com/evalphobia/go-jp-text-ripper/tokenizer"
+)
+
+// NewPIIPlugins returns the plugins to output masked text and the count of each masked data.
+// Use with prefilter.PII to mask email, phone number, postal code, credit card number and url.
+// preFilters are the prefilters running before prefilter.PII (e.g. markup removal),
+// and the data is counted on the text of the same step as the masking.
+func NewPIIPlugins(preFilters ...*ripper.PreFilter) []*ripper.Plugin {
+	return []*ripper.Plugin{
+		PIINameMaskPlugin,
+		PIINameCountPlugin,
+		newPIICountPlugin("pii_email_count", preFilters, func(c prefilter.PIICount) int { return c.Email }),
+		newPIICountPlugin("pii_phone_count", preFilters, func(c prefilter.PIICount) int { return c.Phone }),
+		newPIICountPlugin("pii_postal_count", preFilters, func(c prefilter.PIICount) int { return c.Postal }),
+		newPIICountPlugin("pii_card_count", preFilters, func(c prefilter.PIICount) int { return c.Card }),
+		newPIICountPlugin("pii_url_count", preFilters, func(c prefilter.PIICount) int { return c.URL }),
+	}
+}
+
+// PIINameMaskPlugin outputs normalized text masked person names.
+var PIINameMaskPlugin = &ripper.Plugin{
+	Title: "pii_masked_text",
+	Fn: func(text *ripper.TextData) string {
+		return MaskPIIText(text)
+	},
+}
+
+// MaskPIIText returns normalized text masked person names.
+// Use with prefilter.PII for ripper.RipConfig.TextMask to mask the target column of the output.
+func MaskPIIText(text *ripper.TextData) string {
+	return maskNames(text.GetNormalized(), text.GetAllTokens())
+}
+
+// MaskPIIWord returns the placeholder for the person name token.
+// Use for ripper.RipConfig.WordMask to mask the words of the output.
+func MaskPIIWord(t *tokenizer.Token) (string, bool) {
+	if !isNameToken(t) {
+		return "", false
+	}
+	return prefilter.MaskName, true
+}
+
+// PIINameCountPlugin calculates person name count from tokenized words.
+// consecutive name words (e.g. 姓 and 名) are counted as one.
+var PIINameCountPlugin = &ripper.Plugin{
+	Title: "pii_name_count",
+	Fn: func(text *ripper.TextData) string {
+		return strconv.Itoa(len(findNameRanges(text.GetAllTokens())))
+	},
+}
+
+// newPIICountPlugin returns the plugin to output the count of the masked data.
+func newPIICountPlugin(title string, preFilters []*ripper.PreFilter, fn func(prefilter.PIICount) int) *ripper.Plugin {
+	return &ripper.Plugin{
+		Title: title,
+		Fn: func(text *ripper.TextData) string {
+			return strconv.Itoa(fn(countPII(text.GetRaw(), preFilters)))
+		},
+	}
+}
+
+// countPII applies the prefilters and counts the personal data masked by prefilter.PII.
+func countPII(raw string, preFilters []*ripper.PreFilter) prefilter.PIICount {
+	for _, f := range preFilters {
+		raw = f.Fn(raw)
+	}
+	_, cnt := prefilter.MaskPIIWithCount(raw)
+	return cnt
+}
+
+// isNameToken checks the token is a person name or not.
+// suffix (e.g. さん, 様) is not a name.
+func isNameToken(t *tokenizer.Token) bool {
+	if t.HasFeature("接尾") {
+		return false
+	}
+	return t.HasFeature("人名") || t.HasFeature("姓") || t.HasFeature("名")
+}
+
+// findNameRanges returns rune ranges of the person names.
+func findNameRanges(tokens *tokenizer.TokenList) [][2]int {
+	var ranges [][2]int
+	for _, t := range tokens.List {
+		if !isNameToken(t) {
+			continue
+		}
+		if n := len(ranges); n != 0 && ranges[n-1][1] == t.Start {
+			ranges[n-1][1] = t.End
+			continue
+		}
+		ranges = append(ranges, [2]int{t.Start, t.End})
+	}
+	return ranges
+}
+
+// maskNames replaces person names in the text with the placeholder.
+func maskNames(text string, tokens *tokenizer.TokenList) string {
+	ranges := findNameRanges(tokens)
+	if len(ranges) == 0 {
+		return text
+	}
+
+	runes := []rune(text)
+	var b strings.Builder
+	last := 0
+	for _, r := range ranges {
+		if r[0] < last || r[1] > len(runes) {
+			continue
+		}
+		b.WriteString(string(runes[last:r[0]]))
+		b.WriteString(prefilter.MaskName)
+		last = r[1]
+	}
+	b.WriteString(string(runes[last:]))
+	return b.String()
+}
@@ -0,0 +1,226 @@
+package plugin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+	"github.com/evalphobia/go-jp-text-ripper/tokenizer"
+)
+
+// negationLookAhead is max number of tokens to find negation after the expression.
+const negationLookAhead = 3
+
+// negationForms are original forms of auxiliary verbs to flip the polarity.
+var negationForms = map[string]struct{}{
+	"ない": {},
+	"ず":  {},
+	"ぬ":  {},
+}
+
+// SentimentLexicon is polarity dictionary for SentimentPlugins.
+//
+// The lexicon file is TSV format, and each line contains an expression and its polarity.
+//
+//	<expression>\t<polarity>
+//
+// The expression is original form (i.e. 原形) of the word, and multiple words are separated by space (e.g. "気持ち が 良い").
+// The polarity is one of 'p' (positive), 'n' (negative), 'e' (neutral) or number (e.g. 0.5, -2).
+// Empty lines and lines starting with '#' are ignored.
+type SentimentLexicon struct {
+	entries map[string][]sentimentEntry // first word => entries
+}
+
+type sentimentEntry struct {
+	words    []string
+	polarity float64
+}
+
+// LoadSentimentLexicon loads the lexicon from file.
+func LoadSentimentLexicon(path string) (*SentimentLexicon, error) {
+	/* #nosec G304 */
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close() // #nosec G307
+
+	return NewSentimentLexicon(fp)
+}
+
+// NewSentimentLexicon reads the lexicon from io.Reader.
+func NewSentimentLexicon(r io.Reader) (*SentimentLexicon, error) {
+	lex := &SentimentLexicon{
+		entries: make(map[string][]sentimentEntry),
+	}
+
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cols := strings.Split(line, "\t")
+		if len(cols) < 2 {
+			return nil, fmt.Errorf("invalid lexicon format on line:[%d] text:[%s]", lineNo, line)
+		}
+		polarity, err := parsePolarity(strings.TrimSpace(cols[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid polarity on line:[%d] err:[%s]", lineNo, err.Error())
+		}
+		lex.Add(strings.Fields(cols[0]), polarity)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return lex, nil
+}
+
+func parsePolarity(s string) (float64, error) {
+	switch s {
+	case "p", "positive", "+":
+		return 1, nil
+	case "n", "negative", "-":
+		return -1, nil
+	case "e", "neutral":
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// Add adds the expression into the lexicon.
+func (l *SentimentLexicon) Add(words []string, polarity float64) {
+	if len(words) == 0 {
+		return
+	}
+	l.entries[words[0]] = append(l.entries[words[0]], sentimentEntry{
+		words:    words,
+		polarity: polarity,
+	})
+}
+
+// match returns the longest expression from the position of the words.
+func (l *SentimentLexicon) match(words []string, pos int) (sentimentEntry, bool) {
+	var result sentimentEntry
+	found := false
+	for _, e := range l.entries[words[pos]] {
+		if len(e.words) <= len(result.words) || pos+len(e.words) > len(words) {
+			continue
+		}
+		matched := true
+		for i, w := range e.words {
+			if words[pos+i] != w {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = e
+			found = true
+		}
+	}
+	return result, found
+}
+
+// sentimentResult is the result of sentiment scoring.
+type sentimentResult struct {
+	positive int
+	negative int
+	score    float64
+}
+
+// Score calculates positive count, negative count and normalized score (-1.0 ~ 1.0) of the tokens.
+func (l *SentimentLexicon) Score(tokens *tokenizer.TokenList) (positive, negative int, score float64) {
+	r := l.score(tokens)
+	return r.positive, r.negative, r.score
+}
+
+func (l *SentimentLexicon) score(tokens *tokenizer.TokenList) sentimentResult {
+	words := make([]string, len(tokens.List))
+	for i, t := range tokens.List {
+		words[i] = t.GetOriginalForm()
+	}
+
+	result := sentimentResult{}
+	sum, abs := 0.0, 0.0
+	for i := 0; i < len(words); i++ {
+		e, ok := l.match(words, i)
+		if !ok {
+			continue
+		}
+		i += len(e.words) - 1
+
+		polarity := e.polarity
+		if hasNegation(tokens.List, i+1) {
+			polarity = -polarity
+		}
+		switch {
+		case polarity > 0:
+			result.positive++
+		case polarity < 0:
+			result.negative++
+		}
+		sum += polarity
+		abs += math.Abs(polarity)
+	}
+	if abs > 0 {
+		result.score = sum / abs
+	}
+	return result
+}
+
+// hasNegation checks negation (ない, ず, ぬ) follows from the position.
+func hasNegation(list []*tokenizer.Token, pos int) bool {
+	for i := pos; i < len(list) && i < pos+negationLookAhead; i++ {
+		t := list[i]
+		if _, ok := negationForms[t.GetOriginalForm()]; ok {
+			return true
+		}
+		switch t.GetPos() {
+		case "記号", "名詞":
+			return false
+		}
+	}
+	return false
+}
+
+// NewSentimentPlugins returns the plugins to output positive count, negative count and normalized score.
+func NewSentimentPlugins(lex *SentimentLexicon) []*ripper.Plugin {
+	// cache the result for the same text
+	var lastText *ripper.TextData
+	var lastResult sentimentResult
+	score := func(text *ripper.TextData) sentimentResult {
+		if text != lastText {
+			lastText = text
+			lastResult = lex.score(text.GetAllTokens())
+		}
+		return lastResult
+	}
+
+	return []*ripper.Plugin{
+		{
+			Title: "sentiment_positive",
+			Fn: func(text *ripper.TextData) string {
+				return strconv.Itoa(score(text).positive)
+			},
+		},
+		{
+			Title: "sentiment_negative",
+			Fn: func(text *ripper.TextData) string {
+				return strconv.Itoa(score(text).negative)
+			},
+		},
+		{
+			Title: "sentiment_score",
+			Fn: func(text *ripper.TextData) string {
+				return strconv.FormatFloat(score(text).score, 'f', 5, 64)
+			},
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evalphobia/go-jp-text-ripper/tokenizer"
+)
+
+func TestSentimentLexiconScore(t *testing.T) {
+	lex, err := NewSentimentLexicon(strings.NewReader("# comment\n" +
+		"良い\tp\n" +
+		"悪い\tn\n" +
+		"気持ち が 良い\t2\n" +
+		"普通\te\n"))
+	if err != nil {
+		t.Fatalf("NewSentimentLexicon() err = %v", err)
+	}
+	tok := tokenizer.New(tokenizer.Config{})
+
+	tests := []struct {
+		text     string
+		positive int
+		negative int
+		score    float64
+	}{
+		{"天気が良い", 1, 0, 1},
+		{"天気が悪い", 0, 1, -1},
+		{"良いが悪い", 1, 1, 0},
+		// negation flips the polarity
+		{"天気が良くない", 0, 1, -1},
+		{"天気は悪くない", 1, 0, 1},
+		// negation after the noun is not applied
+		{"良い天気ではない", 1, 0, 1},
+		// the longest expression is matched
+		{"気持ちが良い。悪い", 1, 1, 1.0 / 3},
+		{"普通", 0, 0, 0},
+		{"", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		positive, negative, score := lex.Score(tok.TokenizeAll(tt.text))
+		if positive != tt.positive || negative != tt.negative || score != tt.score {
+			t.Errorf("Score(%q) = (%d, %d, %v), want (%d, %d, %v)", tt.text, positive, negative, score, tt.positive, tt.negative, tt.score)
+		}
+	}
+}
+
+func TestNewSentimentLexiconError(t *testing.T) {
+	tests := []string{
+		"良い",
+		"良い\tx",
+	}
+
+	for _, tt := range tests {
+		if _, err := NewSentimentLexicon(strings.NewReader(tt)); err == nil {
+			t.Errorf("NewSentimentLexicon(%q) err = nil, want error", tt)
+		}
+	}
+}
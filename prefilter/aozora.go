@@ -0,0 +1,150 @@
+package prefilter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// Aozora is prefilter to remove markup of Aozora Bunko (ruby, editor notes and gaiji notation).
+// The rune offsets of the text are not kept.
+var Aozora = &ripper.PreFilter{
+	Title: "aozora",
+	Fn: func(rawText string) string {
+		return StripAozora(rawText)
+	},
+}
+
+const (
+	aozoraRubyMarker = '｜'
+	aozoraRubyStart  = '《'
+	aozoraRubyEnd    = '》'
+	// geta mark is used for unknown gaiji
+	aozoraGeta = "〓"
+)
+
+var (
+	reAozoraGaiji = regexp.MustCompile(`※［＃[^］]*］`)
+	reAozoraNote  = regexp.MustCompile(`［＃[^］]*］`)
+	reAozoraUCS   = regexp.MustCompile(`U\+([0-9A-Fa-f]{4,6})`)
+)
+
+// AozoraRuby is a ruby text in Aozora Bunko.
+type AozoraRuby struct {
+	Base    string
+	Reading string
+}
+
+// StripAozora removes markup of Aozora Bunko.
+// Gaiji notation is resolved by the unicode code point (e.g. 'U+5F45'), or replaced with '〓'.
+func StripAozora(text string) string {
+	text = resolveAozoraGaiji(text)
+	text = reAozoraNote.ReplaceAllString(text, "")
+	text, _ = parseAozoraRuby(text)
+	return text
+}
+
+// ExtractAozoraRuby returns ruby texts (base and reading) in the text.
+func ExtractAozoraRuby(text string) []AozoraRuby {
+	text = resolveAozoraGaiji(text)
+	text = reAozoraNote.ReplaceAllString(text, "")
+	_, list := parseAozoraRuby(text)
+	return list
+}
+
+// resolveAozoraGaiji replaces gaiji notation (e.g. '※［＃「弓＋椁のつくり」、第3水準1-84-22］') with the character.
+func resolveAozoraGaiji(text string) string {
+	return reAozoraGaiji.ReplaceAllStringFunc(text, func(s string) string {
+		m := reAozoraUCS.FindStringSubmatch(s)
+		if len(m) < 2 {
+			return aozoraGeta
+		}
+		code, err := strconv.ParseUint(m[1], 16, 32)
+		if err != nil || code > unicode.MaxRune {
+			return aozoraGeta
+		}
+		return string(rune(code))
+	})
+}
+
+// parseAozoraRuby removes ruby markup and returns the text and ruby list.
+// The ruby base starts from '｜', or the kanji sequence before '《' when '｜' does not exist.
+// '｜' is used as the marker only when '《…》' follows in the same run.
+func parseAozoraRuby(text string) (string, []AozoraRuby) {
+	if !strings.ContainsRune(text, aozoraRubyStart) && !strings.ContainsRune(text, aozoraRubyMarker) {
+		return text, nil
+	}
+
+	runes := []rune(text)
+	result := make([]rune, 0, len(runes))
+	var list []AozoraRuby
+	marker := -1 // position of the ruby marker in result
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case aozoraRubyMarker:
+			// unmatched marker is kept in the text
+			if !hasAozoraRuby(runes, i+1) {
+				break
+			}
+			marker = len(result)
+			continue
+		case aozoraRubyStart:
+			end := indexRune(runes, aozoraRubyEnd, i+1)
+			if end < 0 {
+				break
+			}
+
+			start := marker
+			if start < 0 {
+				start = len(result)
+				for start > 0 && isAozoraKanji(result[start-1]) {
+					start--
+				}
+			}
+			list = append(list, AozoraRuby{
+				Base:    string(result[start:]),
+				Reading: string(runes[i+1 : end]),
+			})
+			marker = -1
+			i = end
+			continue
+		}
+		result = append(result, r)
+	}
+	return string(result), list
+}
+
+// hasAozoraRuby checks '《…》' follows from the position before the next marker, newline or period.
+func hasAozoraRuby(runes []rune, from int) bool {
+	for i := from; i < len(runes); i++ {
+		switch runes[i] {
+		case aozoraRubyStart:
+			return indexRune(runes, aozoraRubyEnd, i+1) >= 0
+		case aozoraRubyMarker, aozoraRubyEnd, '\n', '\r', '。':
+			return false
+		}
+	}
+	return false
+}
+
+func indexRune(runes []rune, r rune, from int) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// isAozoraKanji checks the character is a part of the kanji sequence for ruby.
+func isAozoraKanji(r rune) bool {
+	switch r {
+	case '々', '〆', '〇', 'ヶ', '〓':
+		return true
+	}
+	return unicode.Is(unicode.Han, r)
+}
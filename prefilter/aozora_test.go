@@ -0,0 +1,54 @@
+package prefilter
+
+import "testing"
+
+func TestStripAozora(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"吾輩《わがはい》は猫である", "吾輩は猫である"},
+		{"｜東京《とうきょう》の猫", "東京の猫"},
+		{"一番｜獰悪《どうあく》な種族", "一番獰悪な種族"},
+		{"猫である［＃「猫である」に傍点］", "猫である"},
+		{"※［＃「弓＋椁のつくり」、U+5F49、2-1］", "\u5f49"},
+		{"※［＃「未知」、第3水準1-1-1］", "〓"},
+		// unmatched marker is kept
+		{"A｜B。それから吾輩《わがはい》は", "A｜B。それから吾輩は"},
+		{"一人｜の男。", "一人｜の男。"},
+		// unmatched ruby start is kept
+		{"吾輩《わがはい", "吾輩《わがはい"},
+	}
+
+	for _, tt := range tests {
+		if got := StripAozora(tt.text); got != tt.expected {
+			t.Errorf("StripAozora(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestExtractAozoraRuby(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []AozoraRuby
+	}{
+		{"吾輩《わがはい》は猫である", []AozoraRuby{{Base: "吾輩", Reading: "わがはい"}}},
+		{"一番｜獰悪《どうあく》な種族", []AozoraRuby{{Base: "獰悪", Reading: "どうあく"}}},
+		{"｜ｋｅｙ《キー》", []AozoraRuby{{Base: "ｋｅｙ", Reading: "キー"}}},
+		// stray marker does not extend the next ruby base
+		{"A｜B。それから吾輩《わがはい》は", []AozoraRuby{{Base: "吾輩", Reading: "わがはい"}}},
+	}
+
+	for _, tt := range tests {
+		got := ExtractAozoraRuby(tt.text)
+		if len(got) != len(tt.expected) {
+			t.Errorf("ExtractAozoraRuby(%q) = %+v, want %+v", tt.text, got, tt.expected)
+			continue
+		}
+		for i, v := range got {
+			if v != tt.expected[i] {
+				t.Errorf("ExtractAozoraRuby(%q)[%d] = %+v, want %+v", tt.text, i, v, tt.expected[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,597 @@
+package prefilter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// EmojiName is prefilter to replace emoji with the word of CLDR short name by the bundled table.
+// (e.g. '😂' -> 'FaceWithTearsOfJoy', '👍🏽' -> 'ThumbsUp', '🇯🇵' -> 'FlagJP')
+var EmojiName = &ripper.PreFilter{
+	Title: "emoji_name",
+	Fn: func(rawText string) string {
+		return ReplaceEmojiName(rawText)
+	},
+}
+
+const (
+	runeZWJ             = '\u200d'
+	runeVS15            = '\ufe0e'
+	runeVS16            = '\ufe0f'
+	runeKeycap          = '\u20e3'
+	runeSkinToneBegin   = '\U0001F3FB'
+	runeSkinToneEnd     = '\U0001F3FF'
+	runeRegionalBegin   = '\U0001F1E6'
+	runeRegionalEnd     = '\U0001F1FF'
+	runeTagBegin        = '\U000E0020'
+	runeTagEnd          = '\U000E007F'
+	runePictographBegin = '\U0001F000'
+	runePictographEnd   = '\U0001FAFF'
+)
+
+// emojiPresentation is the set of BMP characters displayed as emoji without the variation selector.
+// other BMP symbols (e.g. '❤', '☀') are treated as emoji only with U+FE0F.
+var emojiPresentation = map[rune]struct{}{}
+
+func init() {
+	for _, r := range "⌚⌛⏩⏪⏫⏬⏰⏳◽◾☔☕♈♉♊♋♌♍♎♏♐♑♒♓♿⚓⚡⚪⚫⚽⚾⛄⛅⛎⛔⛪⛲⛳⛵⛺⛽✅✊✋✨❌❎❓❔❕❗➕➖➗➰➿⬛⬜⭐⭕" {
+		emojiPresentation[r] = struct{}{}
+	}
+}
+
+// FindEmoji returns emoji in the text.
+// ZWJ sequences (e.g. '👨‍👩‍👧'), skin tones (e.g. '👍🏽'), flags (e.g. '🇯🇵') and keycaps (e.g. '1️⃣') are treated as one emoji.
+func FindEmoji(s string) []string {
+	var list []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		end := scanEmoji(runes, i)
+		if end == i {
+			i++
+			continue
+		}
+		list = append(list, string(runes[i:end]))
+		i = end
+	}
+	return list
+}
+
+// scanEmoji returns the end position of the emoji sequence starting from i.
+// it returns i when the character is not emoji.
+func scanEmoji(runes []rune, i int) int {
+	size := len(runes)
+	r := runes[i]
+
+	// flag
+	if isRegionalIndicator(r) {
+		if i+1 < size && isRegionalIndicator(runes[i+1]) {
+			return i + 2
+		}
+		return i + 1
+	}
+
+	// keycap (e.g. '1️⃣')
+	if r < unicode.MaxASCII && (r == '#' || r == '*' || ('0' <= r && r <= '9')) {
+		j := i + 1
+		if j < size && runes[j] == runeVS16 {
+			j++
+		}
+		if j < size && runes[j] == runeKeycap {
+			return j + 1
+		}
+		return i
+	}
+
+	j := scanEmojiElement(runes, i)
+	if j == i {
+		return i
+	}
+	for j+1 < size && runes[j] == runeZWJ {
+		next := scanEmojiElement(runes, j+1)
+		if next == j+1 {
+			break
+		}
+		j = next
+	}
+	return j
+}
+
+// scanEmojiElement returns the end position of an emoji with modifiers.
+func scanEmojiElement(runes []rune, i int) int {
+	size := len(runes)
+	r := runes[i]
+	j := i + 1
+	switch {
+	case j < size && runes[j] == runeVS15:
+		// text presentation
+		return i
+	case isPictograph(r):
+	case j < size && runes[j] == runeVS16:
+	default:
+		if _, ok := emojiPresentation[r]; !ok {
+			return i
+		}
+	}
+
+	if j < size && isSkinTone(runes[j]) {
+		j++
+	}
+	if j < size && runes[j] == runeVS16 {
+		j++
+	}
+	for j < size && isTag(runes[j]) {
+		j++
+	}
+	return j
+}
+
+func isPictograph(r rune) bool {
+	return runePictographBegin <= r && r <= runePictographEnd
+}
+
+func isRegionalIndicator(r rune) bool {
+	return runeRegionalBegin <= r && r <= runeRegionalEnd
+}
+
+func isSkinTone(r rune) bool {
+	return runeSkinToneBegin <= r && r <= runeSkinToneEnd
+}
+
+func isTag(r rune) bool {
+	return runeTagBegin <= r && r <= runeTagEnd
+}
+
+// EmojiTable is the mapping table of emoji to CLDR short name.
+type EmojiTable map[string]string
+
+// NewEmojiNamePreFilter returns the prefilter to replace emoji with the word of CLDR short name by the bundled table and the user's table file.
+// The file is TSV format, and each line contains an emoji and its name (e.g. "🍣\tsushi").
+func NewEmojiNamePreFilter(path string) (*ripper.PreFilter, error) {
+	table := NewEmojiTable()
+	if path != "" {
+		if err := table.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return &ripper.PreFilter{
+		Title: "emoji_name",
+		Fn:    table.Replace,
+	}, nil
+}
+
+// NewEmojiTable returns a copy of the bundled table.
+func NewEmojiTable() EmojiTable {
+	t := make(EmojiTable, len(emojiTable))
+	for k, v := range emojiTable {
+		t[k] = v
+	}
+	return t
+}
+
+// LoadFile adds the mapping from the file.
+func (t EmojiTable) LoadFile(path string) error {
+	/* #nosec G304 */
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close() // #nosec G307
+
+	return t.Load(fp)
+}
+
+// Load adds the mapping from io.Reader.
+// Empty lines and lines starting with '#' are ignored.
+func (t EmojiTable) Load(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cols := strings.SplitN(line, "\t", 2)
+		if len(cols) < 2 || len(FindEmoji(cols[0])) != 1 || strings.TrimSpace(cols[1]) == "" {
+			return fmt.Errorf("invalid emoji format on line:[%d] text:[%s]", lineNo, line)
+		}
+		t[emojiKey(cols[0])] = strings.TrimSpace(cols[1])
+	}
+	return sc.Err()
+}
+
+// Name returns CLDR short name of the emoji.
+// skin tones and variation selectors are ignored, and ZWJ sequences not in the table are named from each emoji.
+func (t EmojiTable) Name(emoji string) (string, bool) {
+	key := emojiKey(emoji)
+	if name, ok := t[key]; ok {
+		return name, true
+	}
+
+	runes := []rune(key)
+	switch {
+	case len(runes) == 2 && isRegionalIndicator(runes[0]) && isRegionalIndicator(runes[1]):
+		return "flag " + string([]rune{
+			runes[0] - runeRegionalBegin + 'A',
+			runes[1] - runeRegionalBegin + 'A',
+		}), true
+	case len(runes) == 2 && runes[1] == runeKeycap:
+		return "keycap " + string(runes[0]), true
+	case strings.ContainsRune(key, runeZWJ):
+		parts := strings.Split(key, string(runeZWJ))
+		names := make([]string, len(parts))
+		for i, p := range parts {
+			name, ok := t[p]
+			if !ok {
+				return "", false
+			}
+			names[i] = name
+		}
+		return strings.Join(names, " "), true
+	}
+	return "", false
+}
+
+// Replace replaces emoji in the text with the word of CLDR short name.
+// Unknown emoji are kept.
+func (t EmojiTable) Replace(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(runes); {
+		end := scanEmoji(runes, i)
+		if end == i {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		emoji := string(runes[i:end])
+		i = end
+		name, ok := t.Name(emoji)
+		if !ok {
+			b.WriteString(emoji)
+			continue
+		}
+		// put spaces to separate from the adjacent words
+		b.WriteString(" " + EmojiWord(name) + " ")
+	}
+	return b.String()
+}
+
+// ReplaceEmojiName replaces emoji in the text with the word of CLDR short name by the bundled table.
+func ReplaceEmojiName(s string) string {
+	return emojiTable.Replace(s)
+}
+
+// EmojiWord converts CLDR short name into one word to be tokenized as a word.
+// (e.g. 'face with tears of joy' -> 'FaceWithTearsOfJoy')
+func EmojiWord(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, p := range parts {
+		runes := []rune(p)
+		runes[0] = unicode.ToUpper(runes[0])
+		parts[i] = string(runes)
+	}
+	return strings.Join(parts, "")
+}
+
+// emojiKey removes skin tones and variation selectors from the emoji.
+func emojiKey(emoji string) string {
+	return strings.Map(func(r rune) rune {
+		if r == runeVS16 || isSkinTone(r) {
+			return -1
+		}
+		return r
+	}, emoji)
+}
+
+// emojiTable is the bundled mapping of the common emoji to CLDR short name.
+var emojiTable = EmojiTable{
+	"😀":       "grinning face",
+	"😁":       "beaming face with smiling eyes",
+	"😂":       "face with tears of joy",
+	"😃":       "grinning face with big eyes",
+	"😄":       "grinning face with smiling eyes",
+	"😅":       "grinning face with sweat",
+	"😆":       "grinning squinting face",
+	"😇":       "smiling face with halo",
+	"😈":       "smiling face with horns",
+	"😉":       "winking face",
+	"😊":       "smiling face with smiling eyes",
+	"😋":       "face savoring food",
+	"😌":       "relieved face",
+	"😍":       "smiling face with heart-eyes",
+	"😎":       "smiling face with sunglasses",
+	"😏":       "smirking face",
+	"😐":       "neutral face",
+	"😑":       "expressionless face",
+	"😒":       "unamused face",
+	"😓":       "downcast face with sweat",
+	"😔":       "pensive face",
+	"😕":       "confused face",
+	"😖":       "confounded face",
+	"😗":       "kissing face",
+	"😘":       "face blowing a kiss",
+	"😙":       "kissing face with smiling eyes",
+	"😚":       "kissing face with closed eyes",
+	"😛":       "face with tongue",
+	"😜":       "winking face with tongue",
+	"😝":       "squinting face with tongue",
+	"😞":       "disappointed face",
+	"😟":       "worried face",
+	"😠":       "angry face",
+	"😡":       "pouting face",
+	"😢":       "crying face",
+	"😣":       "persevering face",
+	"😤":       "face with steam from nose",
+	"😥":       "sad but relieved face",
+	"😦":       "frowning face with open mouth",
+	"😧":       "anguished face",
+	"😨":       "fearful face",
+	"😩":       "weary face",
+	"😪":       "sleepy face",
+	"😫":       "tired face",
+	"😬":       "grimacing face",
+	"😭":       "loudly crying face",
+	"😮":       "face with open mouth",
+	"😯":       "hushed face",
+	"😰":       "anxious face with sweat",
+	"😱":       "face screaming in fear",
+	"😲":       "astonished face",
+	"😳":       "flushed face",
+	"😴":       "sleeping face",
+	"😵":       "dizzy face",
+	"😶":       "face without mouth",
+	"😷":       "face with medical mask",
+	"😸":       "grinning cat face with smiling eyes",
+	"😹":       "cat face with tears of joy",
+	"😺":       "grinning cat face",
+	"😻":       "smiling cat face with heart-eyes",
+	"😼":       "cat face with wry smile",
+	"😽":       "kissing cat face",
+	"😾":       "pouting cat face",
+	"😿":       "crying cat face",
+	"🙀":       "weary cat face",
+	"🙁":       "slightly frowning face",
+	"🙂":       "slightly smiling face",
+	"🙃":       "upside-down face",
+	"🙄":       "face with rolling eyes",
+	"🙅":       "person gesturing no",
+	"🙆":       "person gesturing OK",
+	"🙇":       "person bowing",
+	"🙈":       "see-no-evil monkey",
+	"🙉":       "hear-no-evil monkey",
+	"🙊":       "speak-no-evil monkey",
+	"🙋":       "person raising hand",
+	"🙌":       "raising hands",
+	"🙍":       "person frowning",
+	"🙎":       "person pouting",
+	"🙏":       "folded hands",
+	"🤐":       "zipper-mouth face",
+	"🤑":       "money-mouth face",
+	"🤒":       "face with thermometer",
+	"🤓":       "nerd face",
+	"🤔":       "thinking face",
+	"🤕":       "face with head-bandage",
+	"🤖":       "robot",
+	"🤗":       "hugging face",
+	"🤘":       "sign of the horns",
+	"🤙":       "call me hand",
+	"🤚":       "raised back of hand",
+	"🤛":       "left-facing fist",
+	"🤜":       "right-facing fist",
+	"🤝":       "handshake",
+	"🤞":       "crossed fingers",
+	"🤟":       "love-you gesture",
+	"🤠":       "cowboy hat face",
+	"🤡":       "clown face",
+	"🤢":       "nauseated face",
+	"🤣":       "rolling on the floor laughing",
+	"🤤":       "drooling face",
+	"🤥":       "lying face",
+	"🤦":       "person facepalming",
+	"🤧":       "sneezing face",
+	"🤨":       "face with raised eyebrow",
+	"🤩":       "star-struck",
+	"🤪":       "zany face",
+	"🤫":       "shushing face",
+	"🤬":       "face with symbols on mouth",
+	"🤭":       "face with hand over mouth",
+	"🤮":       "face vomiting",
+	"🤯":       "exploding head",
+	"🥰":       "smiling face with hearts",
+	"🥱":       "yawning face",
+	"🥲":       "smiling face with tear",
+	"🥳":       "partying face",
+	"🥴":       "woozy face",
+	"🥵":       "hot face",
+	"🥶":       "cold face",
+	"🥷":       "ninja",
+	"🥸":       "disguised face",
+	"🥹":       "face holding back tears",
+	"🥺":       "pleading face",
+	"❤":       "red heart",
+	"🧡":       "orange heart",
+	"💛":       "yellow heart",
+	"💚":       "green heart",
+	"💙":       "blue heart",
+	"💜":       "purple heart",
+	"🖤":       "black heart",
+	"🤍":       "white heart",
+	"🤎":       "brown heart",
+	"💔":       "broken heart",
+	"❣":       "heart exclamation",
+	"💕":       "two hearts",
+	"💞":       "revolving hearts",
+	"💓":       "beating heart",
+	"💗":       "growing heart",
+	"💖":       "sparkling heart",
+	"💘":       "heart with arrow",
+	"💝":       "heart with ribbon",
+	"💟":       "heart decoration",
+	"👍":       "thumbs up",
+	"👎":       "thumbs down",
+	"👏":       "clapping hands",
+	"👐":       "open hands",
+	"✌":       "victory hand",
+	"👌":       "OK hand",
+	"🤏":       "pinching hand",
+	"👈":       "backhand index pointing left",
+	"👉":       "backhand index pointing right",
+	"👆":       "backhand index pointing up",
+	"👇":       "backhand index pointing down",
+	"☝":       "index pointing up",
+	"✋":       "raised hand",
+	"🖐":       "hand with fingers splayed",
+	"🖖":       "vulcan salute",
+	"👋":       "waving hand",
+	"💪":       "flexed biceps",
+	"✊":       "raised fist",
+	"👊":       "oncoming fist",
+	"🔥":       "fire",
+	"✨":       "sparkles",
+	"⭐":       "star",
+	"🌟":       "glowing star",
+	"💫":       "dizzy",
+	"💥":       "collision",
+	"💢":       "anger symbol",
+	"💦":       "sweat droplets",
+	"💧":       "droplet",
+	"💤":       "zzz",
+	"💨":       "dashing away",
+	"🎉":       "party popper",
+	"🎊":       "confetti ball",
+	"🎂":       "birthday cake",
+	"🎁":       "wrapped gift",
+	"🎈":       "balloon",
+	"💯":       "hundred points",
+	"💩":       "pile of poo",
+	"👀":       "eyes",
+	"👁":       "eye",
+	"💋":       "kiss",
+	"💀":       "skull",
+	"☠":       "skull and crossbones",
+	"👻":       "ghost",
+	"👽":       "alien",
+	"🎃":       "jack-o-lantern",
+	"🌸":       "cherry blossom",
+	"🌹":       "rose",
+	"🌻":       "sunflower",
+	"🌷":       "tulip",
+	"🍀":       "four leaf clover",
+	"🍁":       "maple leaf",
+	"🍂":       "fallen leaf",
+	"🌈":       "rainbow",
+	"☀":       "sun",
+	"🌙":       "crescent moon",
+	"⚡":       "high voltage",
+	"❄":       "snowflake",
+	"☔":       "umbrella with rain drops",
+	"☕":       "hot beverage",
+	"🍺":       "beer mug",
+	"🍻":       "clinking beer mugs",
+	"🍷":       "wine glass",
+	"🍣":       "sushi",
+	"🍜":       "steaming bowl",
+	"🍙":       "rice ball",
+	"🍰":       "shortcake",
+	"🍓":       "strawberry",
+	"🍎":       "red apple",
+	"🍑":       "peach",
+	"🍆":       "eggplant",
+	"🐶":       "dog face",
+	"🐱":       "cat face",
+	"🐰":       "rabbit face",
+	"🐻":       "bear face",
+	"🐼":       "panda face",
+	"🐷":       "pig face",
+	"🐸":       "frog face",
+	"🐵":       "monkey face",
+	"🐧":       "penguin",
+	"🐦":       "bird",
+	"🐤":       "baby chick",
+	"🐟":       "fish",
+	"🐍":       "snake",
+	"🦀":       "crab",
+	"⚽":       "soccer ball",
+	"⚾":       "baseball",
+	"🏀":       "basketball",
+	"🎮":       "video game",
+	"🎵":       "musical note",
+	"🎶":       "musical notes",
+	"🎤":       "microphone",
+	"📷":       "camera",
+	"📱":       "mobile phone",
+	"💻":       "laptop computer",
+	"💰":       "money bag",
+	"💸":       "money with wings",
+	"💡":       "light bulb",
+	"📚":       "books",
+	"✏":       "pencil",
+	"📝":       "memo",
+	"📌":       "pushpin",
+	"📍":       "round pushpin",
+	"🚀":       "rocket",
+	"✈":       "airplane",
+	"🚗":       "automobile",
+	"🚃":       "railway car",
+	"🏠":       "house",
+	"🏫":       "school",
+	"🏥":       "hospital",
+	"⏰":       "alarm clock",
+	"⌛":       "hourglass done",
+	"✅":       "check mark button",
+	"❌":       "cross mark",
+	"⭕":       "hollow red circle",
+	"❓":       "red question mark",
+	"❗":       "red exclamation mark",
+	"‼":       "double exclamation mark",
+	"⁉":       "exclamation question mark",
+	"⚠":       "warning",
+	"🚫":       "prohibited",
+	"💬":       "speech balloon",
+	"💭":       "thought balloon",
+	"🆗":       "OK button",
+	"🆕":       "NEW button",
+	"🆙":       "UP! button",
+	"🈁":       "Japanese “here” button",
+	"🉐":       "Japanese “bargain” button",
+	"㊗":       "Japanese “congratulations” button",
+	"㊙":       "Japanese “secret” button",
+	"🔰":       "japanese symbol for beginner",
+	"♻":       "recycling symbol",
+	"👶":       "baby",
+	"👦":       "boy",
+	"👧":       "girl",
+	"👨":       "man",
+	"👩":       "woman",
+	"👴":       "old man",
+	"👵":       "old woman",
+	"🧑":       "adult",
+	"🤷":       "person shrugging",
+	"💁":       "person tipping hand",
+	"🏃":       "person running",
+	"🚶":       "person walking",
+	"💃":       "woman dancing",
+	"🕺":       "man dancing",
+	"👫":       "woman and man holding hands",
+	"👪":       "family",
+	"🫠":       "melting face",
+	"🫶":       "heart hands",
+	"😮‍💨":     "face exhaling",
+	"😵‍💫":     "face with spiral eyes",
+	"😶‍🌫":     "face in clouds",
+	"❤‍🔥":     "heart on fire",
+	"❤‍🩹":     "mending heart",
+	"🧑‍💻":     "technologist",
+	"👨‍👩‍👧‍👦": "family: man, woman, girl, boy",
+	"🏳‍🌈":     "rainbow flag",
+}
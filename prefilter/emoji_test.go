@@ -0,0 +1,66 @@
+package prefilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindEmoji(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"今日は😂です", []string{"😂"}},
+		{"家族👨‍👩‍👧で", []string{"👨‍👩‍👧"}},
+		{"いいね👍🏽👍", []string{"👍🏽", "👍"}},
+		{"日本🇯🇵🇺🇸", []string{"🇯🇵", "🇺🇸"}},
+		{"番号1️⃣", []string{"1️⃣"}},
+		{"好き❤️", []string{"❤️"}},
+		// BMP symbols without VS16 are not emoji
+		{"好き❤", nil},
+		{"晴れ⭐", []string{"⭐"}},
+		{"絵文字なし 123", nil},
+	}
+
+	for _, tt := range tests {
+		if got := FindEmoji(tt.text); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("FindEmoji(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestReplaceEmojiName(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"今日は😂です", "今日は FaceWithTearsOfJoy です"},
+		{"いいね👍🏽", "いいね ThumbsUp "},
+		{"日本🇯🇵", "日本 FlagJP "},
+		{"絵文字なし", "絵文字なし"},
+	}
+
+	for _, tt := range tests {
+		if got := ReplaceEmojiName(tt.text); got != tt.expected {
+			t.Errorf("ReplaceEmojiName(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestEmojiWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"face with tears of joy", "FaceWithTearsOfJoy"},
+		{"thumbs up", "ThumbsUp"},
+		{"family: man, woman, girl, boy", "FamilyManWomanGirlBoy"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := EmojiWord(tt.name); got != tt.expected {
+			t.Errorf("EmojiWord(%q) = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
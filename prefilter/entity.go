@@ -0,0 +1,157 @@
+package prefilter
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// placeholders for protected entities.
+// MaskURL and MaskEmail are also used for the entities.
+const (
+	MaskHashtag = "<HASHTAG>"
+	MaskMention = "<MENTION>"
+)
+
+// Entity is prefilter to protect url, email, hashtag and mention from tokenization by replacing them with the placeholders.
+// (e.g. 'https://example.com' -> '<URL>', '#ハッシュタグ' -> '<HASHTAG>', '@user' -> '<MENTION>')
+var Entity = &ripper.PreFilter{
+	Title: "entity",
+	Fn: func(rawText string) string {
+		return ProtectEntities(rawText)
+	},
+}
+
+var (
+	reEntityURL     = regexp.MustCompile(`(?:https?://|www\.)[\w!?/+\-~;.,*&@#$%()'=:\[\]]+`)
+	reEntityHashtag = regexp.MustCompile(`(^|[^\w&/])[#＃]([\p{L}\p{N}_ー・]+)`)
+	reEntityMention = regexp.MustCompile(`(^|[^\w@.])[@＠]([A-Za-z0-9_]{1,15})`)
+)
+
+// Entities contains the entities extracted from the text.
+type Entities struct {
+	URLs     []string
+	Emails   []string
+	Hashtags []string
+	Mentions []string
+}
+
+// ExtractEntities returns url, email, hashtag and mention in the text.
+func ExtractEntities(s string) Entities {
+	e := Entities{}
+	replaceEntities(s, &e)
+	return e
+}
+
+// ProtectEntities replaces url, email, hashtag and mention in the text with the placeholders.
+func ProtectEntities(s string) string {
+	return replaceEntities(s, &Entities{})
+}
+
+// Domains returns the host names of the urls and the emails in lower case.
+// leading 'www.' is removed (e.g. 'https://www.Example.com/path' -> 'example.com').
+func (e Entities) Domains() []string {
+	list := make([]string, 0, len(e.URLs)+len(e.Emails))
+	for _, u := range e.URLs {
+		if d := urlDomain(u); d != "" {
+			list = append(list, d)
+		}
+	}
+	for _, m := range e.Emails {
+		if i := strings.LastIndexByte(m, '@'); i >= 0 {
+			list = append(list, strings.ToLower(m[i+1:]))
+		}
+	}
+	return list
+}
+
+// replaceEntities replaces the entities with the placeholders, and adds them into e.
+// urls and emails are replaced at first, not to detect the parts of them as hashtag and mention.
+func replaceEntities(s string, e *Entities) string {
+	s = replaceURLs(s, e)
+	s = rePIIEmail.ReplaceAllStringFunc(s, func(m string) string {
+		e.Emails = append(e.Emails, m)
+		return MaskEmail
+	})
+	s = replaceWithPrefix(s, reEntityHashtag, MaskHashtag, func(name string) bool {
+		// digits only is not hashtag (e.g. '#1')
+		if strings.IndexFunc(name, func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+			return false
+		}
+		e.Hashtags = append(e.Hashtags, "#"+name)
+		return true
+	})
+	return replaceWithPrefix(s, reEntityMention, MaskMention, func(name string) bool {
+		e.Mentions = append(e.Mentions, "@"+name)
+		return true
+	})
+}
+
+// replaceURLs replaces urls with the placeholder.
+// trailing punctuation and unbalanced parenthesis are not treated as a part of url.
+func replaceURLs(s string, e *Entities) string {
+	matches := reEntityURL.FindAllStringIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[0]+len(trimURL(s[m[0]:m[1]]))
+		e.URLs = append(e.URLs, s[start:end])
+		b.WriteString(s[last:start])
+		b.WriteString(MaskURL)
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func trimURL(u string) string {
+	for len(u) > 0 {
+		r, size := utf8.DecodeLastRuneInString(u)
+		switch {
+		case strings.ContainsRune(".,;:!?'", r):
+		case r == ')' && strings.Count(u, "(") < strings.Count(u, ")"):
+		case r == ']' && strings.Count(u, "[") < strings.Count(u, "]"):
+		default:
+			return u
+		}
+		u = u[:len(u)-size]
+	}
+	return u
+}
+
+// replaceWithPrefix replaces the second group of the pattern with the mask, and keeps the first group.
+// the match is kept when fn returns false.
+func replaceWithPrefix(s string, re *regexp.Regexp, mask string, fn func(name string) bool) string {
+	return re.ReplaceAllStringFunc(s, func(m string) string {
+		sub := re.FindStringSubmatch(m)
+		if !fn(sub[2]) {
+			return m
+		}
+		return sub[1] + mask
+	})
+}
+
+// urlDomain returns the host name of the url in lower case.
+func urlDomain(u string) string {
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+	}
+	if i := strings.IndexAny(u, "/?#"); i >= 0 {
+		u = u[:i]
+	}
+	if i := strings.LastIndexByte(u, '@'); i >= 0 {
+		u = u[i+1:]
+	}
+	if i := strings.LastIndexByte(u, ':'); i >= 0 {
+		u = u[:i]
+	}
+	u = strings.ToLower(u)
+	return strings.TrimPrefix(u, "www.")
+}
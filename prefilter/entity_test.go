@@ -0,0 +1,79 @@
+package prefilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractEntities(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Entities
+	}{
+		{
+			"詳細は https://example.com/path?q=1 を見て",
+			Entities{URLs: []string{"https://example.com/path?q=1"}},
+		},
+		// trailing punctuation and unbalanced parenthesis are removed
+		{
+			"(www.example.com/a). 見てね",
+			Entities{URLs: []string{"www.example.com/a"}},
+		},
+		{
+			"https://en.wikipedia.org/wiki/Go_(language) です",
+			Entities{URLs: []string{"https://en.wikipedia.org/wiki/Go_(language)"}},
+		},
+		{
+			"連絡先 user.name@example.co.jp まで",
+			Entities{Emails: []string{"user.name@example.co.jp"}},
+		},
+		{
+			"#ハッシュタグ と ＃東京 と #1",
+			Entities{Hashtags: []string{"#ハッシュタグ", "#東京"}},
+		},
+		{
+			"@user_1 さんと＠taro",
+			Entities{Mentions: []string{"@user_1", "@taro"}},
+		},
+		// '#' in the url is not hashtag
+		{
+			"https://example.com/#top #tag",
+			Entities{URLs: []string{"https://example.com/#top"}, Hashtags: []string{"#tag"}},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractEntities(tt.text); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ExtractEntities(%q) = %+v, want %+v", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestProtectEntities(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"見て https://example.com.", "見て " + MaskURL + "."},
+		{"user@example.com へ", MaskEmail + " へ"},
+		{"今日は#晴れ @taro", "今日は" + MaskHashtag + " " + MaskMention},
+		{"エンティティなし", "エンティティなし"},
+	}
+
+	for _, tt := range tests {
+		if got := ProtectEntities(tt.text); got != tt.expected {
+			t.Errorf("ProtectEntities(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestEntitiesDomains(t *testing.T) {
+	e := Entities{
+		URLs:   []string{"https://www.Example.com/path", "http://user@host.example.org:8080/"},
+		Emails: []string{"taro@Mail.Example.JP"},
+	}
+	expected := []string{"example.com", "host.example.org", "mail.example.jp"}
+	if got := e.Domains(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Domains() = %q, want %q", got, expected)
+	}
+}
@@ -0,0 +1,58 @@
+package prefilter
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// These prefilters are used as ripper.CommonConfig.KeyFilters to unify the word variants for matching and ranking.
+
+// KanaFold is prefilter to convert katakana into hiragana (e.g. 'リンゴ' -> 'りんご').
+var KanaFold = &ripper.PreFilter{
+	Title: "kana_fold",
+	Fn: func(rawText string) string {
+		return FoldKana(rawText)
+	},
+}
+
+// CaseFold is prefilter to convert alphabets into lower case.
+var CaseFold = &ripper.PreFilter{
+	Title: "case_fold",
+	Fn: func(rawText string) string {
+		return strings.ToLower(rawText)
+	},
+}
+
+// WidthFold is prefilter to convert full-width alphanumerics into half-width, and half-width katakana into full-width.
+var WidthFold = &ripper.PreFilter{
+	Title: "width_fold",
+	Fn: func(rawText string) string {
+		return FoldWidth(rawText)
+	},
+}
+
+const (
+	katakanaStart = 'ァ'
+	katakanaEnd   = 'ヶ'
+	kanaDiff      = 'ァ' - 'ぁ'
+)
+
+// FoldKana converts katakana into hiragana.
+func FoldKana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= katakanaStart && r <= katakanaEnd {
+			return r - kanaDiff
+		}
+		return r
+	}, s)
+}
+
+// FoldWidth converts full-width alphanumerics into half-width, and half-width katakana into full-width.
+// The voiced sound marks are composed (e.g. 'ｺﾞ' -> 'ゴ').
+func FoldWidth(s string) string {
+	return norm.NFC.String(width.Fold.String(s))
+}
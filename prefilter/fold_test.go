@@ -0,0 +1,38 @@
+package prefilter
+
+import "testing"
+
+func TestFoldKana(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"カタカナ", "かたかな"},
+		{"ヴァイオリン", "ゔぁいおりん"},
+		{"漢字とABC", "漢字とABC"},
+	}
+
+	for _, tt := range tests {
+		if got := FoldKana(tt.text); got != tt.expected {
+			t.Errorf("FoldKana(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestFoldWidth(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"ＡＢＣ１２３", "ABC123"},
+		{"ｺﾞﾘﾗ", "ゴリラ"},
+		{"ﾊﾟﾝ", "パン"},
+		{"全角かな", "全角かな"},
+	}
+
+	for _, tt := range tests {
+		if got := FoldWidth(tt.text); got != tt.expected {
+			t.Errorf("FoldWidth(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package prefilter
+
+import (
+	"html"
+	"regexp"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// HTML is prefilter to remove html tags, scripts and styles, and decode html entities.
+// The rune offsets of the text are kept by spaces.
+var HTML = &ripper.PreFilter{
+	Title: "html",
+	Fn: func(rawText string) string {
+		return StripHTML(rawText)
+	},
+}
+
+var (
+	reHTMLComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+	reHTMLScript  = regexp.MustCompile(`(?is)<script\b[^>]*>.*?</script\s*>`)
+	reHTMLStyle   = regexp.MustCompile(`(?is)<style\b[^>]*>.*?</style\s*>`)
+	reHTMLTag     = regexp.MustCompile(`</?[A-Za-z!?][^>]*>`)
+	reHTMLEntity  = regexp.MustCompile(`&(?:#[0-9]+|#[xX][0-9A-Fa-f]+|[A-Za-z][A-Za-z0-9]*);?`)
+)
+
+// StripHTML removes html tags and decodes html entities.
+func StripHTML(text string) string {
+	text = blankAll(text, reHTMLComment)
+	text = blankAll(text, reHTMLScript)
+	text = blankAll(text, reHTMLStyle)
+	text = blankAll(text, reHTMLTag)
+	return reHTMLEntity.ReplaceAllStringFunc(text, func(s string) string {
+		decoded := html.UnescapeString(s)
+		if decoded == "\u00a0" {
+			// &nbsp;
+			decoded = " "
+		}
+		return pad(s, decoded)
+	})
+}
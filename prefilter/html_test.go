@@ -0,0 +1,77 @@
+package prefilter
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"<p>吾輩は<b>猫</b>である</p>", []string{"吾輩は", "猫", "である"}},
+		{"<script>var a = 1;</script>本文", []string{"本文"}},
+		{"<style>p { color: red; }</style>本文", []string{"本文"}},
+		{"<!-- comment -->本文", []string{"本文"}},
+		// decoded entity is padded by spaces
+		{"A&amp;B&nbsp;C", []string{"A&", "B", "C"}},
+		{"&#x732B;&#29483;", []string{"猫", "猫"}},
+		{"1 < 2", []string{"1", "<", "2"}},
+	}
+
+	for _, tt := range tests {
+		got := StripHTML(tt.text)
+		assertSameRuneCount(t, "StripHTML", tt.text, got)
+		if fields := strings.Fields(got); strings.Join(fields, "|") != strings.Join(tt.expected, "|") {
+			t.Errorf("StripHTML(%q) = %q, want %v", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestStripMarkdown(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"# 見出し", []string{"見出し"}},
+		{"**強調**と*斜体*", []string{"強調", "と", "斜体"}},
+		{"[リンク](https://example.com)を参照", []string{"リンク", "を参照"}},
+		{"- 項目1\n- 項目2", []string{"項目1", "項目2"}},
+		{"> 引用", []string{"引用"}},
+		{"```\ncode\n```\n本文", []string{"本文"}},
+		{"`code`です", []string{"code", "です"}},
+		{"snake_case_word", []string{"snake_case_word"}},
+	}
+
+	for _, tt := range tests {
+		got := StripMarkdown(tt.text)
+		assertSameRuneCount(t, "StripMarkdown", tt.text, got)
+		if fields := strings.Fields(got); strings.Join(fields, "|") != strings.Join(tt.expected, "|") {
+			t.Errorf("StripMarkdown(%q) = %q, want %v", tt.text, got, tt.expected)
+		}
+	}
+}
+
+// TestStripHTMLOffset checks the visible text keeps the rune offsets of the raw text.
+func TestStripHTMLOffset(t *testing.T) {
+	raw := "<p>吾輩は<b>猫</b>である</p>"
+	got := []rune(StripHTML(raw))
+	rawRunes := []rune(raw)
+	for _, word := range []string{"吾輩は", "猫", "である"} {
+		pos := strings.Index(raw, word)
+		start := utf8.RuneCountInString(raw[:pos])
+		end := start + utf8.RuneCountInString(word)
+		if string(got[start:end]) != string(rawRunes[start:end]) {
+			t.Errorf("StripHTML(%q) offset of %q = %q", raw, word, string(got[start:end]))
+		}
+	}
+}
+
+func assertSameRuneCount(t *testing.T, name, raw, got string) {
+	t.Helper()
+	if utf8.RuneCountInString(raw) != utf8.RuneCountInString(got) {
+		t.Errorf("%s(%q) = %q, rune count is changed", name, raw, got)
+	}
+}
@@ -0,0 +1,136 @@
+package prefilter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// Itaiji is prefilter to normalize kanji variants (e.g. 舊字體 -> 旧字体) by the bundled table.
+// The rune offsets of the text are kept.
+var Itaiji = &ripper.PreFilter{
+	Title: "itaiji",
+	Fn: func(rawText string) string {
+		return NormalizeItaiji(rawText)
+	},
+}
+
+// ItaijiTable is the mapping table of kanji variants to the canonical form.
+type ItaijiTable map[rune]rune
+
+// NewItaijiPreFilter returns the prefilter to normalize kanji variants by the bundled table and the user's table file.
+// The file is TSV format, and each line contains a variant and its canonical form (e.g. "髙\t高").
+func NewItaijiPreFilter(path string) (*ripper.PreFilter, error) {
+	table := NewItaijiTable()
+	if path != "" {
+		if err := table.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return &ripper.PreFilter{
+		Title: "itaiji",
+		Fn:    table.Normalize,
+	}, nil
+}
+
+// NewItaijiTable returns a copy of the bundled table.
+func NewItaijiTable() ItaijiTable {
+	t := make(ItaijiTable, len(itaijiTable))
+	for k, v := range itaijiTable {
+		t[k] = v
+	}
+	return t
+}
+
+// LoadFile adds the mapping from the file.
+func (t ItaijiTable) LoadFile(path string) error {
+	/* #nosec G304 */
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close() // #nosec G307
+
+	return t.Load(fp)
+}
+
+// Load adds the mapping from io.Reader.
+// Empty lines and lines starting with '#' are ignored.
+func (t ItaijiTable) Load(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cols := strings.Fields(line)
+		if len(cols) < 2 || utf8.RuneCountInString(cols[0]) != 1 || utf8.RuneCountInString(cols[1]) != 1 {
+			return fmt.Errorf("invalid itaiji format on line:[%d] text:[%s]", lineNo, line)
+		}
+		from, _ := utf8.DecodeRuneInString(cols[0])
+		to, _ := utf8.DecodeRuneInString(cols[1])
+		t[from] = to
+	}
+	return sc.Err()
+}
+
+// Normalize replaces kanji variants in the text with the canonical form.
+func (t ItaijiTable) Normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if v, ok := t[r]; ok {
+			return v
+		}
+		return r
+	}, s)
+}
+
+// NormalizeItaiji replaces kanji variants in the text with the canonical form by the bundled table.
+func NormalizeItaiji(s string) string {
+	return itaijiTable.Normalize(s)
+}
+
+// itaijiTable is the bundled mapping of old-form (旧字体) and variant kanji to the standard form (新字体).
+var itaijiTable = ItaijiTable{
+	'亞': '亜', '惡': '悪', '壓': '圧', '圍': '囲', '爲': '為', '醫': '医', '壹': '壱', '稻': '稲',
+	'飮': '飲', '隱': '隠', '營': '営', '榮': '栄', '衞': '衛', '驛': '駅', '圓': '円', '艷': '艶',
+	'鹽': '塩', '奧': '奥', '應': '応', '歐': '欧', '毆': '殴', '穩': '穏', '假': '仮', '價': '価',
+	'畫': '画', '會': '会', '壞': '壊', '懷': '懐', '繪': '絵', '擴': '拡', '殼': '殻', '覺': '覚',
+	'學': '学', '嶽': '岳', '樂': '楽', '勸': '勧', '卷': '巻', '歡': '歓', '罐': '缶', '觀': '観',
+	'關': '関', '陷': '陥', '巖': '巌', '顏': '顔', '歸': '帰', '氣': '気', '龜': '亀', '僞': '偽',
+	'戲': '戯', '犧': '犠', '舊': '旧', '據': '拠', '擧': '挙', '峽': '峡', '挾': '挟', '狹': '狭',
+	'曉': '暁', '區': '区', '驅': '駆', '勳': '勲', '徑': '径', '惠': '恵', '溪': '渓', '經': '経',
+	'繼': '継', '莖': '茎', '螢': '蛍', '輕': '軽', '鷄': '鶏', '藝': '芸', '缺': '欠', '儉': '倹',
+	'劍': '剣', '圈': '圏', '檢': '検', '權': '権', '獻': '献', '縣': '県', '險': '険', '顯': '顕',
+	'驗': '験', '嚴': '厳', '效': '効', '廣': '広', '恆': '恒', '鑛': '鉱', '號': '号', '國': '国',
+	'濟': '済', '碎': '砕', '齋': '斎', '劑': '剤', '櫻': '桜', '册': '冊', '雜': '雑', '參': '参',
+	'慘': '惨', '棧': '桟', '蠶': '蚕', '贊': '賛', '殘': '残', '絲': '糸', '齒': '歯', '兒': '児',
+	'辭': '辞', '濕': '湿', '實': '実', '舍': '舎', '寫': '写', '釋': '釈', '壽': '寿', '收': '収',
+	'從': '従', '澁': '渋', '獸': '獣', '縱': '縦', '肅': '粛', '處': '処', '敍': '叙', '奬': '奨',
+	'將': '将', '燒': '焼', '稱': '称', '證': '証', '乘': '乗', '剩': '剰', '壤': '壌', '孃': '嬢',
+	'條': '条', '淨': '浄', '疊': '畳', '穰': '穣', '讓': '譲', '釀': '醸', '囑': '嘱', '觸': '触',
+	'寢': '寝', '愼': '慎', '眞': '真', '盡': '尽', '圖': '図', '粹': '粋', '醉': '酔', '隨': '随',
+	'髓': '髄', '數': '数', '樞': '枢', '聲': '声', '靜': '静', '齊': '斉', '攝': '摂', '竊': '窃',
+	'專': '専', '戰': '戦', '淺': '浅', '潛': '潜', '纖': '繊', '踐': '践', '錢': '銭', '禪': '禅',
+	'雙': '双', '壯': '壮', '搜': '捜', '插': '挿', '爭': '争', '總': '総', '聰': '聡', '莊': '荘',
+	'裝': '装', '騷': '騒', '增': '増', '藏': '蔵', '臟': '臓', '卽': '即', '屬': '属', '續': '続',
+	'墮': '堕', '對': '対', '體': '体', '帶': '帯', '滯': '滞', '臺': '台', '瀧': '滝', '擇': '択',
+	'澤': '沢', '單': '単', '擔': '担', '膽': '胆', '團': '団', '彈': '弾', '斷': '断', '癡': '痴',
+	'遲': '遅', '晝': '昼', '蟲': '虫', '鑄': '鋳', '廳': '庁', '聽': '聴', '敕': '勅', '鎭': '鎮',
+	'遞': '逓', '鐵': '鉄', '轉': '転', '點': '点', '傳': '伝', '黨': '党', '盜': '盗', '燈': '灯',
+	'當': '当', '鬭': '闘', '獨': '独', '讀': '読', '屆': '届', '繩': '縄', '貳': '弐', '惱': '悩',
+	'腦': '脳', '霸': '覇', '廢': '廃', '拜': '拝', '賣': '売', '麥': '麦', '發': '発', '髮': '髪',
+	'拔': '抜', '蠻': '蛮', '祕': '秘', '濱': '浜', '甁': '瓶', '拂': '払', '佛': '仏', '竝': '並',
+	'變': '変', '邊': '辺', '邉': '辺', '辨': '弁', '瓣': '弁', '辯': '弁', '舖': '舗', '步': '歩',
+	'穗': '穂', '寶': '宝', '豐': '豊', '沒': '没', '飜': '翻', '每': '毎', '萬': '万', '滿': '満',
+	'默': '黙', '譯': '訳', '藥': '薬', '與': '与', '豫': '予', '餘': '余', '譽': '誉', '搖': '揺',
+	'樣': '様', '謠': '謡', '來': '来', '賴': '頼', '亂': '乱', '覽': '覧', '龍': '竜', '兩': '両',
+	'獵': '猟', '壘': '塁', '勵': '励', '禮': '礼', '靈': '霊', '齡': '齢', '戀': '恋', '爐': '炉',
+	'勞': '労', '樓': '楼', '錄': '録', '灣': '湾', '髙': '高', '﨑': '崎', '德': '徳', '濵': '浜',
+	'嶋': '島', '𠮷': '吉', '槪': '概',
+}
@@ -0,0 +1,44 @@
+package prefilter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeItaiji(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"學校", "学校"},
+		{"國際", "国際"},
+		{"舊字體", "旧字体"},
+		{"新字体", "新字体"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeItaiji(tt.text); got != tt.expected {
+			t.Errorf("NormalizeItaiji(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestItaijiTableLoad(t *testing.T) {
+	table := NewItaijiTable()
+	if err := table.Load(strings.NewReader("# comment\n\n甲\t乙\n")); err != nil {
+		t.Fatalf("Load() err = %v", err)
+	}
+	if got := table.Normalize("甲學"); got != "乙学" {
+		t.Errorf("Normalize() = %q, want %q", got, "乙学")
+	}
+	// the bundled table is not changed
+	if got := NormalizeItaiji("甲"); got != "甲" {
+		t.Errorf("NormalizeItaiji() = %q, want %q", got, "甲")
+	}
+
+	for _, text := range []string{"髙", "髙橋\t高橋"} {
+		if err := NewItaijiTable().Load(strings.NewReader(text)); err == nil {
+			t.Errorf("Load(%q) should return error", text)
+		}
+	}
+}
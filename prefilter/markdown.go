@@ -0,0 +1,54 @@
+package prefilter
+
+import (
+	"regexp"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// Markdown is prefilter to remove markdown syntax (code blocks, link targets, emphasis markers, etc.) and keep visible text.
+// The rune offsets of the text are kept by spaces.
+var Markdown = &ripper.PreFilter{
+	Title: "markdown",
+	Fn: func(rawText string) string {
+		return StripMarkdown(rawText)
+	},
+}
+
+var (
+	reMarkdownCodeBlock  = regexp.MustCompile("(?ms)^[ \t]*(?:```|~~~).*?^[ \t]*(?:```|~~~)[ \t]*$")
+	reMarkdownReference  = regexp.MustCompile(`(?m)^[ ]{0,3}\[[^\]\n]+\]:[ \t]*\S+.*$`)
+	reMarkdownRule       = regexp.MustCompile(`(?m)^[ \t]*(?:[-*_][ \t]*){3,}$`)
+	reMarkdownHeading    = regexp.MustCompile(`(?m)^[ \t]*#{1,6}[ \t]+`)
+	reMarkdownQuote      = regexp.MustCompile(`(?m)^[ \t]*(?:>[ \t]?)+`)
+	reMarkdownList       = regexp.MustCompile(`(?m)^[ \t]*(?:[-*+]|\d+[.)])[ \t]+`)
+	reMarkdownLink       = regexp.MustCompile(`!?\[([^\]\n]*)\]\([^)\n]*\)`)
+	reMarkdownRefLink    = regexp.MustCompile(`!?\[([^\]\n]*)\]\[[^\]\n]*\]`)
+	reMarkdownInlineCode = regexp.MustCompile("`([^`\n]+)`")
+	reMarkdownStrong     = regexp.MustCompile(`\*\*([^*\n]+)\*\*`)
+	reMarkdownStrongU    = regexp.MustCompile(`__([^_\n]+)__`)
+	reMarkdownStrike     = regexp.MustCompile(`~~([^~\n]+)~~`)
+	reMarkdownEmphasis   = regexp.MustCompile(`\*([^*\s][^*\n]*)\*`)
+	reMarkdownEmphasisU  = regexp.MustCompile(`(^|[^\w])_([^_\s][^_\n]*)_([^\w]|$)`)
+)
+
+// StripMarkdown removes markdown syntax and keeps visible text.
+func StripMarkdown(text string) string {
+	// block
+	text = blankAll(text, reMarkdownCodeBlock)
+	text = blankAll(text, reMarkdownReference)
+	text = blankAll(text, reMarkdownRule)
+	text = blankAll(text, reMarkdownHeading)
+	text = blankAll(text, reMarkdownQuote)
+	text = blankAll(text, reMarkdownList)
+
+	// inline
+	text = blankExceptGroups(text, reMarkdownLink, 1)
+	text = blankExceptGroups(text, reMarkdownRefLink, 1)
+	text = blankExceptGroups(text, reMarkdownInlineCode, 1)
+	text = blankExceptGroups(text, reMarkdownStrong, 1)
+	text = blankExceptGroups(text, reMarkdownStrongU, 1)
+	text = blankExceptGroups(text, reMarkdownStrike, 1)
+	text = blankExceptGroups(text, reMarkdownEmphasis, 1)
+	return blankExceptGroups(text, reMarkdownEmphasisU, 1, 2, 3)
+}
@@ -0,0 +1,48 @@
+package prefilter
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// NFKC is prefilter to normalize text by Unicode NFKC.
+// Tilde, wave dash, hyphen and bar are normalized same as Neologd.
+var NFKC = &ripper.PreFilter{
+	Title: "nfkc",
+	Fn: func(rawText string) string {
+		return NormalizeNFKC(rawText)
+	},
+}
+
+// nfkcReplacer replaces characters before NFKC as Japanese-specific exceptions.
+var nfkcReplacer = strings.NewReplacer(
+	// hyphen
+	"\u02D7", "-", "\u058A", "-", "\u2010", "-", "\u2011", "-", "\u2012", "-",
+	"\u2013", "-", "\u2043", "-", "\u207B", "-", "\u208B", "-", "\u2212", "-",
+
+	// bar
+	"\u2014", string(prolongedSoundMark), // エムダッシュ
+	"\u2015", string(prolongedSoundMark), // ホリゾンタルバー
+	"\u2500", string(prolongedSoundMark), // 横細罫線
+	"\u2501", string(prolongedSoundMark), // 横太罫線
+	"\uFE63", string(prolongedSoundMark), // SMALL HYPHEN-MINUS
+	"\uFF0D", string(prolongedSoundMark), // 全角ハイフンマイナス
+	"\uFF70", string(prolongedSoundMark), // 半角長音記号
+
+	// tilde
+	"~", "", "\u223C", "", "\u223E", "", "\u301C", "", "\u3030", "", "\uFF5E", "",
+
+	// NFKC converts them into space and combining character
+	"\u309B", "\u3099", // 濁点
+	"\u309C", "\u309A", // 半濁点
+)
+
+// NormalizeNFKC normalizes text by Unicode NFKC.
+// '・' (katakana middle dot) is kept, and half-width '･' is converted to '・'.
+func NormalizeNFKC(s string) string {
+	s = nfkcReplacer.Replace(s)
+	return norm.NFKC.String(s)
+}
@@ -0,0 +1,27 @@
+package prefilter
+
+import "testing"
+
+func TestNormalizeNFKC(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"ＡＢＣ１２３", "ABC123"},
+		{"ｱｲｳｴｵ", "アイウエオ"},
+		{"ｶﾞｷﾞ", "ガギ"},
+		{"①", "1"},
+		{"㈱", "(株)"},
+		{"ｰ", "ー"},
+		{"－", "ー"},
+		{"‐", "-"},
+		{"〜", ""},
+		{"･", "・"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeNFKC(tt.text); got != tt.expected {
+			t.Errorf("NormalizeNFKC(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
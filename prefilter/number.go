@@ -0,0 +1,268 @@
+package prefilter
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// MaskNumber is the placeholder for number words.
+// Set it into ripper.CommonConfig.NumberMask with Number prefilter, to replace the number words after tokenization.
+const MaskNumber = "<NUM>"
+
+// Number is prefilter to convert number expressions into arabic numerals.
+// (e.g. '三千五百' -> '3500', '3,500' -> '3500', '3.5千' -> '3500', '1億2千万' -> '120000000')
+// Plain digits without kanji, units and commas are only converted into half-width to keep identifiers
+// (e.g. '０９０' -> '090', '100-0001' -> '100-0001').
+var Number = &ripper.PreFilter{
+	Title: "number",
+	Fn: func(rawText string) string {
+		return NormalizeNumbers(rawText)
+	},
+}
+
+var (
+	reNumberExpr   = regexp.MustCompile(`(?:[0-9０-９]+(?:[,，][0-9０-９]{3})*(?:[.．][0-9０-９]+)?|[〇零一二三四五六七八九壱弐参十拾百千万萬億兆])+`)
+	reNumberArabic = regexp.MustCompile(`^[0-9０-９]+(?:[,，][0-9０-９]{3})*(?:[.．][0-9０-９]+)?`)
+	reNumberPlain  = regexp.MustCompile(`^[0-9０-９]+(?:[.．][0-9０-９]+)?$`)
+)
+
+var kanjiDigits = map[rune]int64{
+	'〇': 0, '零': 0, '一': 1, '二': 2, '三': 3, '四': 4,
+	'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+	'壱': 1, '弐': 2, '参': 3,
+}
+
+// exponent of 10 for the kanji units.
+var kanjiSmallUnits = map[rune]int{
+	'十': 1, '拾': 1, '百': 2, '千': 3,
+}
+
+var kanjiLargeUnits = map[rune]int{
+	'万': 4, '萬': 4, '億': 8, '兆': 12,
+}
+
+// NumberExpr is a number expression in the text.
+type NumberExpr struct {
+	Text  string
+	Value *big.Rat
+}
+
+// FormatValue returns the value as arabic numerals (e.g. '3500', '3.14').
+// Plain digits are only converted into half-width, and the leading zeros are kept (e.g. '007').
+func (n NumberExpr) FormatValue() string {
+	if reNumberPlain.MatchString(n.Text) {
+		return normalizeDigits(n.Text)
+	}
+	return formatRat(n.Value)
+}
+
+// FindNumbers returns number expressions in the text.
+// One kanji numeral is not treated as a number, because it's a part of a word in most cases (e.g. '一緒', '九州').
+func FindNumbers(s string) []NumberExpr {
+	var list []NumberExpr
+	replaceNumbers(s, func(n NumberExpr) string {
+		list = append(list, n)
+		return n.Text
+	})
+	return list
+}
+
+// NormalizeNumbers converts number expressions in the text into arabic numerals.
+func NormalizeNumbers(s string) string {
+	return replaceNumbers(s, NumberExpr.FormatValue)
+}
+
+// replaceNumbers replaces number expressions with the result of fn.
+func replaceNumbers(s string, fn func(NumberExpr) string) string {
+	matches := reNumberExpr.FindAllStringIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		// large unit cannot be the first (e.g. '万一')
+		for start < end {
+			r, size := utf8.DecodeRuneInString(s[start:])
+			if _, ok := kanjiLargeUnits[r]; !ok {
+				break
+			}
+			start += size
+		}
+		text := s[start:end]
+		if !isNumberBoundary(s, start) || isSingleKanji(text) || isKanjiWord(s, start, end) {
+			continue
+		}
+		v, ok := ParseNumber(text)
+		if !ok {
+			continue
+		}
+
+		b.WriteString(s[last:start])
+		b.WriteString(fn(NumberExpr{Text: text, Value: v}))
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// isNumberBoundary checks the previous character is not an ascii letter (e.g. 'mp3', 'A4').
+func isNumberBoundary(s string, start int) bool {
+	prev, _ := utf8.DecodeLastRuneInString(s[:start])
+	return prev == utf8.RuneError || prev >= utf8.RuneSelf || !unicode.IsLetter(prev)
+}
+
+func isSingleKanji(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+	return size == len(s) && r >= utf8.RuneSelf && !unicode.IsDigit(r)
+}
+
+// numberCounters are the first characters of counters and units after kanji numerals (e.g. '三十年', '五百円').
+// '代' and '里' are not included, because they are used in the place names (e.g. '八千代', '九十九里').
+const numberCounters = "年月日時分秒円人個回歳才件本枚冊台匹頭羽杯部階号番度倍歩点位名社校軒着組問曲話巻票店戸週通世区箇"
+
+// isKanjiWord checks the kanji numerals are a part of a word, not a number.
+// the numerals followed by the kanji other than counters are a part of a word (e.g. '八百屋', '五十嵐'),
+// and positional numerals without '〇' must be followed by a counter (e.g. '一九八四年', but not '第一四半期').
+func isKanjiWord(s string, start, end int) bool {
+	text := s[start:end]
+	if strings.IndexFunc(text, unicode.IsDigit) >= 0 {
+		return false
+	}
+
+	next, _ := utf8.DecodeRuneInString(s[end:])
+	switch {
+	case strings.ContainsRune(numberCounters, next):
+		return false
+	case isPositionalKanji(text) && !strings.ContainsAny(text, "〇零"):
+		return true
+	}
+	return unicode.Is(unicode.Han, next)
+}
+
+// isPositionalKanji checks the text has only kanji digits without units (e.g. '二〇二四').
+func isPositionalKanji(s string) bool {
+	for _, r := range s {
+		if _, ok := kanjiDigits[r]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseNumber parses japanese number expression.
+// arabic numerals (with comma and full-width), kanji numerals with units (十, 百, 千, 万, 億, 兆)
+// and positional kanji numerals (e.g. '二〇二四') are supported, and they can be mixed (e.g. '3.5千', '1億2345万').
+// The value is calculated by exact rational arithmetic, so long digits do not lose precision.
+func ParseNumber(s string) (*big.Rat, bool) {
+	total, section, current := new(big.Rat), new(big.Rat), new(big.Rat)
+	hasCurrent := false
+	isPrevDigit := false
+	lastSmall, lastLarge := 0, 0
+	for len(s) > 0 {
+		if m := reNumberArabic.FindString(s); m != "" {
+			if hasCurrent {
+				return nil, false
+			}
+			if _, ok := current.SetString(normalizeDigits(m)); !ok {
+				return nil, false
+			}
+			hasCurrent, isPrevDigit = true, false
+			s = s[len(m):]
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		if d, ok := kanjiDigits[r]; ok {
+			switch {
+			case hasCurrent && isPrevDigit:
+				// positional (e.g. '二〇二四')
+				current.Mul(current, big.NewRat(10, 1))
+				current.Add(current, big.NewRat(d, 1))
+			case hasCurrent:
+				return nil, false
+			default:
+				current.SetInt64(d)
+			}
+			hasCurrent, isPrevDigit = true, true
+			continue
+		}
+		if exp, ok := kanjiSmallUnits[r]; ok {
+			if lastSmall != 0 && exp >= lastSmall {
+				return nil, false
+			}
+			n := big.NewRat(1, 1)
+			if hasCurrent {
+				n.Set(current)
+			}
+			section.Add(section, n.Mul(n, pow10(exp)))
+			lastSmall = exp
+			current.SetInt64(0)
+			hasCurrent, isPrevDigit = false, false
+			continue
+		}
+		if exp, ok := kanjiLargeUnits[r]; ok {
+			if lastLarge != 0 && exp >= lastLarge {
+				return nil, false
+			}
+			n := new(big.Rat).Set(section)
+			if hasCurrent {
+				n.Add(n, current)
+			}
+			if n.Sign() == 0 {
+				return nil, false
+			}
+			total.Add(total, n.Mul(n, pow10(exp)))
+			section.SetInt64(0)
+			lastSmall, lastLarge = 0, exp
+			current.SetInt64(0)
+			hasCurrent, isPrevDigit = false, false
+			continue
+		}
+		return nil, false
+	}
+	return total.Add(total, section.Add(section, current)), true
+}
+
+func pow10(exp int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+}
+
+// maxDecimalDigits is the limit of decimal digits for the infinite decimal (e.g. 1/3).
+const maxDecimalDigits = 20
+
+// formatRat returns the decimal string of the value without trailing zeros.
+// the value parsed by ParseNumber always has finite decimal digits.
+func formatRat(v *big.Rat) string {
+	if v.IsInt() {
+		return v.Num().String()
+	}
+	prec := 1
+	for prec < maxDecimalDigits && !new(big.Rat).Mul(v, pow10(prec)).IsInt() {
+		prec++
+	}
+	return v.FloatString(prec)
+}
+
+// normalizeDigits converts full-width digits into half-width, and removes commas.
+func normalizeDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ',' || r == '，':
+			return -1
+		case r == '．':
+			return '.'
+		case '０' <= r && r <= '９':
+			return r - '０' + '0'
+		}
+		return r
+	}, s)
+}
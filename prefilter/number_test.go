@@ -0,0 +1,94 @@
+package prefilter
+
+import "testing"
+
+func TestNormalizeNumbers(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"三千五百円", "3500円"},
+		{"3,500円", "3500円"},
+		{"３５００", "3500"},
+		{"3.5千", "3500"},
+		{"1億2千万人", "120000000人"},
+		{"1億2345万", "123450000"},
+		{"二〇二四年", "2024年"},
+		{"１２．５％", "12.5％"},
+		{"12,345,678,901,234,567,890円", "12345678901234567890円"},
+		// plain digits are kept as identifiers
+		{"090-1234-5678", "090-1234-5678"},
+		{"〒100-0001", "〒100-0001"},
+		{"007", "007"},
+		{"０９０－１２３４", "090－1234"},
+		{"1234567890123456789", "1234567890123456789"},
+		// not a number
+		{"九州に一緒に行く", "九州に一緒に行く"},
+		{"万一の時", "万一の時"},
+		{"mp3", "mp3"},
+		// kanji numerals in words and proper nouns
+		{"第一四半期", "第一四半期"},
+		{"八百屋で買う", "八百屋で買う"},
+		{"五十嵐さん", "五十嵐さん"},
+		{"八千代市", "八千代市"},
+		{"九十九里浜", "九十九里浜"},
+		{"一二を争う", "一二を争う"},
+		// kanji numerals with counters
+		{"一九八四年", "1984年"},
+		{"二十一世紀", "21世紀"},
+		{"五百円と三千", "500円と3000"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeNumbers(tt.text); got != tt.expected {
+			t.Errorf("NormalizeNumbers(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+		ok       bool
+	}{
+		{"三千五百", "3500", true},
+		{"十", "10", true},
+		{"二十一", "21", true},
+		{"1億2345万", "123450000", true},
+		{"3.5千", "3500", true},
+		{"0.1", "0.1", true},
+		{"9999兆9999億", "9999999900000000", true},
+		{"千百", "1100", true},
+		{"百千", "", false},
+		{"万", "", false},
+		{"abc", "", false},
+	}
+
+	for _, tt := range tests {
+		v, ok := ParseNumber(tt.text)
+		if ok != tt.ok {
+			t.Errorf("ParseNumber(%q) ok = %v, want %v", tt.text, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got := formatRat(v); got != tt.expected {
+			t.Errorf("ParseNumber(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestFindNumbers(t *testing.T) {
+	list := FindNumbers("三千五百円と007番")
+	expected := []string{"3500", "007"}
+	if len(list) != len(expected) {
+		t.Fatalf("FindNumbers() size = %d, want %d", len(list), len(expected))
+	}
+	for i, n := range list {
+		if got := n.FormatValue(); got != expected[i] {
+			t.Errorf("FindNumbers()[%d] = %q, want %q", i, got, expected[i])
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package prefilter
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Some prefilters replace removed text with spaces instead of deleting it,
+// to keep the rune offsets of the normalized text same as the raw text.
+
+// blank returns spaces of the same rune count as the text, newlines are kept.
+func blank(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		if r == '\n' {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// pad returns the replaced text padded by spaces to the same rune count as the original text.
+// the original text is returned when the replaced text is longer than it.
+func pad(original, replaced string) string {
+	diff := utf8.RuneCountInString(original) - utf8.RuneCountInString(replaced)
+	if diff < 0 {
+		return original
+	}
+	return replaced + strings.Repeat(" ", diff)
+}
+
+// blankAll replaces all of the matched text with spaces.
+func blankAll(text string, re *regexp.Regexp) string {
+	return re.ReplaceAllStringFunc(text, blank)
+}
+
+// blankExceptGroups replaces the matched text with spaces except the submatch groups.
+func blankExceptGroups(text string, re *regexp.Regexp, groups ...int) string {
+	matches := re.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	last := 0
+	for _, m := range matches {
+		pos := m[0]
+		b.WriteString(text[last:pos])
+		for _, g := range groups {
+			start, end := m[g*2], m[g*2+1]
+			if start < 0 {
+				continue
+			}
+			b.WriteString(blank(text[pos:start]))
+			b.WriteString(text[start:end])
+			pos = end
+		}
+		b.WriteString(blank(text[pos:m[1]]))
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package prefilter
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+// placeholders for masked personal data.
+const (
+	MaskEmail  = "<EMAIL>"
+	MaskPhone  = "<PHONE>"
+	MaskPostal = "<POSTAL>"
+	MaskCard   = "<CARD>"
+	MaskURL    = "<URL>"
+	MaskName   = "<NAME>"
+)
+
+// PII is prefilter to mask personal data (url, email, credit card number, phone number and postal code).
+// Full-width digits are also detected. Use this prefilter after markup removal (the placeholders look like html tags),
+// and before the other prefilters rewrite the digits.
+var PII = &ripper.PreFilter{
+	Title: "pii",
+	Fn: func(rawText string) string {
+		return MaskPII(rawText)
+	},
+}
+
+var (
+	rePIIURL    = regexp.MustCompile(`https?://[\w!?/+\-~;.,*&@#$%()'=:\[\]]+`)
+	rePIIEmail  = regexp.MustCompile(`[\w.%+\-]+@[\w\-]+(?:\.[\w\-]+)*\.[A-Za-z]{2,}`)
+	rePIICard   = regexp.MustCompile(`[0-9０-９]{4}[-－ ]?[0-9０-９]{4}[-－ ]?[0-9０-９]{4}[-－ ]?[0-9０-９]{1,7}`)
+	rePIIPhone  = regexp.MustCompile(`(?:\+81[-－ ]?|[0０])[0-9０-９]{1,4}[-－][0-9０-９]{1,4}[-－][0-9０-９]{3,4}|(?:\+81[-－ ]?|[0０])[0-9０-９]{1,4}[(（][0-9０-９]{1,4}[)）][0-9０-９]{3,4}|(?:\+81|[0０])[0-9０-９]{9,10}`)
+	rePIIPostal = regexp.MustCompile(`〒 ?[0-9０-９]{3}[-－]?[0-9０-９]{4}|[0-9０-９]{3}[-－][0-9０-９]{4}`)
+)
+
+// PIICount is the count of each masked personal data.
+type PIICount struct {
+	Email  int
+	Phone  int
+	Postal int
+	Card   int
+	URL    int
+}
+
+// MaskPII replaces personal data in the text with the placeholders.
+func MaskPII(text string) string {
+	masked, _ := MaskPIIWithCount(text)
+	return masked
+}
+
+// MaskPIIWithCount replaces personal data in the text with the placeholders, and returns the count of each masked data.
+func MaskPIIWithCount(text string) (string, PIICount) {
+	cnt := PIICount{}
+	text = replacePattern(text, rePIIURL, MaskURL, &cnt.URL)
+	text = replacePattern(text, rePIIEmail, MaskEmail, &cnt.Email)
+	text = replaceDigitPattern(text, rePIICard, MaskCard, &cnt.Card, func(digits int) bool {
+		return digits >= 13 && digits <= 19
+	})
+	text = replaceDigitPattern(text, rePIIPhone, MaskPhone, &cnt.Phone, func(digits int) bool {
+		return digits >= 10 && digits <= 12
+	})
+	text = replaceDigitPattern(text, rePIIPostal, MaskPostal, &cnt.Postal, func(digits int) bool {
+		return digits == 7
+	})
+	return text, cnt
+}
+
+// replacePattern replaces matched text with the mask, and adds the number of the replacement into count.
+func replacePattern(text string, re *regexp.Regexp, mask string, count *int) string {
+	return re.ReplaceAllStringFunc(text, func(string) string {
+		*count++
+		return mask
+	})
+}
+
+// replaceDigitPattern replaces matched text which is not a part of the longer digits,
+// and adds the number of the replacement into count.
+func replaceDigitPattern(text string, re *regexp.Regexp, mask string, count *int, isValid func(digits int) bool) string {
+	matches := re.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if !isDigitBoundary(text, start, end) || !isValid(countDigits(text[start:end])) {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(mask)
+		last = end
+		*count++
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// isDigitBoundary checks the previous and next characters are not digit or hyphen.
+func isDigitBoundary(text string, start, end int) bool {
+	if prev, _ := utf8.DecodeLastRuneInString(text[:start]); prev != utf8.RuneError && (unicode.IsDigit(prev) || isHyphen(prev)) {
+		return false
+	}
+	if next, _ := utf8.DecodeRuneInString(text[end:]); next != utf8.RuneError && (unicode.IsDigit(next) || isHyphen(next)) {
+		return false
+	}
+	return true
+}
+
+func isHyphen(r rune) bool {
+	return r == '-' || r == '－'
+}
+
+func countDigits(s string) int {
+	count := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,64 @@
+package prefilter
+
+import "testing"
+
+func TestMaskPIIWithCount(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+		count    PIICount
+	}{
+		{
+			"メールは foo.bar@example.co.jp です",
+			"メールは " + MaskEmail + " です",
+			PIICount{Email: 1},
+		},
+		{
+			"電話は03-1234-5678か090（1234）5678、+81-90-1234-5678まで",
+			"電話は" + MaskPhone + "か" + MaskPhone + "、" + MaskPhone + "まで",
+			PIICount{Phone: 3},
+		},
+		// full-width digits and hyphens
+		{
+			"０９０－１２３４－５６７８",
+			MaskPhone,
+			PIICount{Phone: 1},
+		},
+		{
+			"〒100-0001 東京都",
+			MaskPostal + " 東京都",
+			PIICount{Postal: 1},
+		},
+		{
+			"カード4111-1111-1111-1111で決済",
+			"カード" + MaskCard + "で決済",
+			PIICount{Card: 1},
+		},
+		{
+			"詳細は https://example.com/a?b=1 を参照",
+			"詳細は " + MaskURL + " を参照",
+			PIICount{URL: 1},
+		},
+		// a part of the longer digits is not masked
+		{
+			"注文番号 123-45678",
+			"注文番号 123-45678",
+			PIICount{},
+		},
+		{
+			"在庫は1234個",
+			"在庫は1234個",
+			PIICount{},
+		},
+	}
+
+	for _, tt := range tests {
+		got, count := MaskPIIWithCount(tt.text)
+		if got != tt.expected {
+			t.Errorf("MaskPIIWithCount(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+		if count != tt.count {
+			t.Errorf("MaskPIIWithCount(%q) count = %+v, want %+v", tt.text, count, tt.count)
+		}
+	}
+}
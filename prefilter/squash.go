@@ -0,0 +1,120 @@
+package prefilter
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/evalphobia/go-jp-text-ripper/ripper"
+)
+
+const defaultSquashMaxRepeat = 2
+
+// Squash is prefilter to collapse the repeated characters (e.g. '！！！！' -> '！！', 'すごーーーい' -> 'すごーーい'),
+// and the laughter markers (e.g. 'wwww' -> 'w', '草草草' -> '草').
+var Squash = NewSquashPreFilter(SquashConfig{})
+
+// SquashConfig contains options for the squash prefilter.
+type SquashConfig struct {
+	// runs of the same character beyond this are collapsed (default=2)
+	MaxRepeat int
+	// canonical token to replace the laughter markers (w, 笑, 草).
+	// if it's empty, the laughter markers are collapsed into one character.
+	LaughterToken string
+}
+
+// NewSquashPreFilter returns the prefilter to collapse the repeated characters and the laughter markers.
+func NewSquashPreFilter(c SquashConfig) *ripper.PreFilter {
+	if c.MaxRepeat <= 0 {
+		c.MaxRepeat = defaultSquashMaxRepeat
+	}
+	return &ripper.PreFilter{
+		Title: "squash",
+		Fn: func(rawText string) string {
+			s := SquashLaughter(rawText, c.LaughterToken)
+			return SquashRepeat(s, c.MaxRepeat)
+		},
+	}
+}
+
+var (
+	// lowercase 'w' out of alphanumeric words (e.g. 'すごいwww')
+	reLaughterW = regexp.MustCompile(`(^|[^\w.@/:\-])([wｗ]+)([^\w.@/:\-]|$)`)
+	// '(笑)' or repeated '笑' (e.g. '笑笑笑')
+	reLaughterWarai = regexp.MustCompile(`(?:[(（]笑[)）]|笑{2,})+`)
+	// repeated '草' (e.g. '草草草')
+	reLaughterKusa = regexp.MustCompile(`草{2,}`)
+)
+
+// SquashLaughter replaces the laughter markers with the token.
+// if the token is empty, the laughter markers are collapsed into one character.
+func SquashLaughter(s, token string) string {
+	s = replaceLaughterW(s, token)
+	s = reLaughterWarai.ReplaceAllStringFunc(s, func(m string) string {
+		if token != "" {
+			return token
+		}
+		return "笑"
+	})
+	return reLaughterKusa.ReplaceAllStringFunc(s, func(m string) string {
+		if token != "" {
+			return token
+		}
+		return "草"
+	})
+}
+
+func replaceLaughterW(s, token string) string {
+	var b strings.Builder
+	for {
+		// find one by one, because the boundary characters can be shared by next match
+		m := reLaughterW.FindStringSubmatchIndex(s)
+		if m == nil {
+			b.WriteString(s)
+			return b.String()
+		}
+
+		// the marker is between the boundary groups
+		start, end := m[4], m[5]
+		marker := s[start:end]
+		if token != "" {
+			marker = token
+		} else {
+			r, _ := utf8.DecodeRuneInString(marker)
+			marker = string(r)
+		}
+		b.WriteString(s[:start])
+		b.WriteString(marker)
+		s = s[end:]
+	}
+}
+
+// SquashRepeat collapses runs of the same character beyond maxRepeat.
+// ASCII letters and digits are not collapsed to keep words and numbers (e.g. 'www.example.com', '1000').
+func SquashRepeat(s string, maxRepeat int) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	var prev rune
+	count := 0
+	for _, r := range s {
+		if r == prev {
+			count++
+		} else {
+			prev = r
+			count = 1
+		}
+		if count > maxRepeat && !isSquashExcluded(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isSquashExcluded(r rune) bool {
+	if unicode.IsDigit(r) {
+		return true
+	}
+	return r < utf8.RuneSelf && unicode.IsLetter(r)
+}
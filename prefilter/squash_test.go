@@ -0,0 +1,49 @@
+package prefilter
+
+import "testing"
+
+func TestSquashRepeat(t *testing.T) {
+	tests := []struct {
+		text      string
+		maxRepeat int
+		expected  string
+	}{
+		{"！！！！", 2, "！！"},
+		{"すごーーーい", 2, "すごーーい"},
+		{"すごーーーい", 1, "すごーい"},
+		{"あ", 1, "あ"},
+		// ascii letters and digits are kept
+		{"www.example.com", 1, "www.example.com"},
+		{"1000", 1, "1000"},
+	}
+
+	for _, tt := range tests {
+		if got := SquashRepeat(tt.text, tt.maxRepeat); got != tt.expected {
+			t.Errorf("SquashRepeat(%q, %d) = %q, want %q", tt.text, tt.maxRepeat, got, tt.expected)
+		}
+	}
+}
+
+func TestSquashLaughter(t *testing.T) {
+	tests := []struct {
+		text     string
+		token    string
+		expected string
+	}{
+		{"面白いwwww", "", "面白いw"},
+		{"面白いｗｗｗ", "", "面白いｗ"},
+		{"草草草", "", "草"},
+		{"面白い(笑)笑笑", "", "面白い笑"},
+		{"面白いwww", "<LAUGH>", "面白い<LAUGH>"},
+		// not laughter
+		{"www.example.com", "", "www.example.com"},
+		{"W杯", "", "W杯"},
+		{"草", "", "草"},
+	}
+
+	for _, tt := range tests {
+		if got := SquashLaughter(tt.text, tt.token); got != tt.expected {
+			t.Errorf("SquashLaughter(%q, %q) = %q, want %q", tt.text, tt.token, got, tt.expected)
+		}
+	}
+}
@@ -51,7 +51,12 @@ func (r *Reader) Read() ([]string, error) {
 
 // Close closes file
 func (r *Reader) Close() error {
-	return r.fp.Close()
+	if r.fp == nil {
+		return nil
+	}
+	err := r.fp.Close()
+	r.fp = nil
+	return err
 }
 
 // GetPosition returns position(read line number)
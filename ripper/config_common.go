@@ -35,7 +35,9 @@ type CommonConfig struct {
 	PreFilters  []*PreFilter
 	Plugins     []*Plugin
 	PostFilters []*PostFilter
+	KeyFilters  []*PreFilter // normalize the word key for matching and ranking, the output text is not changed
 	UseNeologd  bool
+	UseNFKC     bool
 
 	// Tokenizer settings:
 	MinLetterSize   int
@@ -45,6 +47,8 @@ type CommonConfig struct {
 	UseNoun         bool
 	UseVerb         bool
 	UseAdjective    bool
+	UseReadingKey   bool   // use the reading of the word as the key for matching and ranking
+	NumberMask      string // placeholder to replace number words (e.g. '<NUM>')
 
 	// Version info
 	Version  string
@@ -84,6 +88,21 @@ func (c CommonConfig) Validate() error {
 	return nil
 }
 
+// GetKeyFunc returns the function to normalize the word key from KeyFilters.
+func (c CommonConfig) GetKeyFunc() func(string) string {
+	if len(c.KeyFilters) == 0 {
+		return nil
+	}
+
+	filters := c.KeyFilters
+	return func(word string) string {
+		for _, f := range filters {
+			word = f.Fn(word)
+		}
+		return word
+	}
+}
+
 // GetPosList returns 'the parts of speech' for tokenizer.
 func (c CommonConfig) GetPosList() []string {
 	var pos []string
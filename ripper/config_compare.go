@@ -0,0 +1,80 @@
+package ripper
+
+import (
+	"fmt"
+)
+
+const (
+	defaultCompareMinCount = 1
+)
+
+// CompareConfig contains options for 'compare' command.
+type CompareConfig struct {
+	CommonConfig
+
+	// reference input file path to compare with the target input
+	Reference string
+	// column name of the label to separate the input into target and reference
+	LabelColumn string
+	// label value of the target corpus
+	TargetLabel string
+
+	// show keywords from the top N
+	TopNumber int
+	// minimum total count of the word in both corpus
+	MinCount int
+	// count as one word if the same word exists in a line.
+	UseUnique bool
+}
+
+// Init initializes config.
+func (c *CompareConfig) Init() error {
+	if c.TopNumber <= 0 {
+		c.TopNumber = defaultTopNumber
+	}
+	if c.MinCount <= 0 {
+		c.MinCount = defaultCompareMinCount
+	}
+	return c.CommonConfig.Init()
+}
+
+// Validate validates config.
+func (c CompareConfig) Validate() error {
+	if err := c.CommonConfig.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.Reference == "" && c.LabelColumn == "":
+		return fmt.Errorf("no reference corpus\nSet -ref <reference file path> (or -label <label column name>)")
+	case c.Reference != "" && c.LabelColumn != "":
+		return fmt.Errorf("cannot use both of -ref and -label")
+	case c.LabelColumn != "" && c.TargetLabel == "":
+		return fmt.Errorf("no target label\nSet -target <label value of the target>")
+	case c.Output == "" && !c.ShowResult:
+		return fmt.Errorf("no output file\nSet -output <output file path> (or set -show option)")
+	}
+	return nil
+}
+
+// UseLabel checks the input is separated by the label column or not.
+func (c CompareConfig) UseLabel() bool {
+	return c.LabelColumn != ""
+}
+
+// getRankConfig returns RankConfig to count words.
+func (c CompareConfig) getRankConfig(input string) RankConfig {
+	common := c.CommonConfig
+	common.Input = input
+	common.Output = ""
+
+	conf := RankConfig{
+		CommonConfig: common,
+		UseUnique:    c.UseUnique,
+		Score:        ScoreCount,
+	}
+	if c.UseLabel() {
+		conf.GroupBy = c.LabelColumn
+	}
+	return conf
+}
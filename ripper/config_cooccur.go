@@ -0,0 +1,73 @@
+package ripper
+
+import (
+	"fmt"
+)
+
+const (
+	defaultCooccurMinCount = 2
+)
+
+// score types for co-occurrence.
+const (
+	ScorePMI    = "pmi"
+	ScoreNPMI   = "npmi"
+	ScoreDice   = "dice"
+	ScoreTScore = "tscore"
+)
+
+// CooccurConfig contains options for 'cooccur' command.
+type CooccurConfig struct {
+	CommonConfig
+
+	// sliding window size of tokens (0 = whole line)
+	WindowSize int
+	// minimum count of the word pair
+	MinCount int
+	// minimum count of each word in the pair
+	MinWordCount int
+	// show word pairs from the top N
+	TopNumber int
+	// score type to sort the pairs (count, pmi, npmi, dice, tscore)
+	Score string
+	// output file path for graph data (.graphml or .gexf)
+	GraphOutput string
+}
+
+// Init initializes config.
+func (c *CooccurConfig) Init() error {
+	if c.TopNumber <= 0 {
+		c.TopNumber = defaultTopNumber
+	}
+	if c.MinCount <= 0 {
+		c.MinCount = defaultCooccurMinCount
+	}
+	if c.Score == "" {
+		c.Score = ScoreCount
+	}
+	return c.CommonConfig.Init()
+}
+
+// Validate validates config.
+func (c CooccurConfig) Validate() error {
+	if err := c.CommonConfig.Validate(); err != nil {
+		return err
+	}
+
+	if c.Output == "" && c.GraphOutput == "" && !c.ShowResult {
+		return fmt.Errorf("no output file\nSet -output <output file path> (or set -graph or -show option)")
+	}
+
+	switch c.Score {
+	case ScoreCount, ScorePMI, ScoreNPMI, ScoreDice, ScoreTScore:
+		// pass
+	default:
+		return fmt.Errorf("invalid score type: [%s]\nSet -score <count|pmi|npmi|dice|tscore>", c.Score)
+	}
+	return nil
+}
+
+// UseWindow checks co-occurrence is counted in the sliding window or not.
+func (c CooccurConfig) UseWindow() bool {
+	return c.WindowSize > 0
+}
@@ -0,0 +1,83 @@
+package ripper
+
+import (
+	"fmt"
+)
+
+const (
+	defaultDedupShingleSize = 3
+	defaultDedupHashSize    = 64
+	defaultDedupBandSize    = 16
+	defaultDedupThreshold   = 0.8
+)
+
+// output modes for 'dedup' command.
+const (
+	DedupModeCluster = "cluster"
+	DedupModeRemove  = "remove"
+)
+
+// DedupConfig contains options for 'dedup' command.
+type DedupConfig struct {
+	CommonConfig
+
+	// output mode (cluster, remove)
+	Mode string
+	// token size of a shingle
+	ShingleSize int
+	// number of hash functions for MinHash
+	HashSize int
+	// number of bands for LSH (HashSize must be divisible by this)
+	BandSize int
+	// minimum estimated jaccard similarity to be treated as duplicate
+	Threshold float64 // 0.0~1.0
+	// seed for hash functions
+	Seed int64
+}
+
+// Init initializes config.
+func (c *DedupConfig) Init() error {
+	if c.Prefix == "" {
+		c.Prefix = defaultPrefix
+	}
+	if c.Mode == "" {
+		c.Mode = DedupModeCluster
+	}
+	if c.ShingleSize <= 0 {
+		c.ShingleSize = defaultDedupShingleSize
+	}
+	if c.HashSize <= 0 {
+		c.HashSize = defaultDedupHashSize
+	}
+	if c.BandSize <= 0 {
+		c.BandSize = defaultDedupBandSize
+	}
+	if c.Threshold <= 0 {
+		c.Threshold = defaultDedupThreshold
+	}
+	return c.CommonConfig.Init()
+}
+
+// Validate validates config.
+func (c DedupConfig) Validate() error {
+	if err := c.CommonConfig.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.Output == "":
+		return fmt.Errorf("no output file\nSet -output <output file path>")
+	case c.HashSize%c.BandSize != 0:
+		return fmt.Errorf("hash size must be divisible by band size: hash:[%d] band:[%d]", c.HashSize, c.BandSize)
+	case c.Threshold > 1:
+		return fmt.Errorf("threshold must be 0.0 ~ 1.0: [%f]", c.Threshold)
+	}
+
+	switch c.Mode {
+	case DedupModeCluster, DedupModeRemove:
+		// pass
+	default:
+		return fmt.Errorf("invalid mode: [%s]\nSet -mode <cluster|remove>", c.Mode)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package ripper
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultKWICContextSize = 5
+)
+
+// KWICConfig contains options for 'kwic' command.
+type KWICConfig struct {
+	CommonConfig
+
+	// query word to search
+	Query string
+	// use query as regular expression
+	UseRegexp bool
+	// features of the part of speech to search (separated by comma)
+	PosPattern string
+	// token size of left and right context
+	ContextSize int
+	// column name to output as an id of the row
+	IDColumn string
+
+	queryRegexp *regexp.Regexp
+	posList     []string
+}
+
+// Init initializes config.
+func (c *KWICConfig) Init() error {
+	if c.ContextSize <= 0 {
+		c.ContextSize = defaultKWICContextSize
+	}
+	if c.UseRegexp && c.Query != "" {
+		re, err := regexp.Compile(c.Query)
+		if err != nil {
+			return fmt.Errorf("invalid query regexp: [%s] err:[%s]", c.Query, err.Error())
+		}
+		c.queryRegexp = re
+	}
+	if c.PosPattern != "" {
+		for _, p := range strings.Split(c.PosPattern, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				c.posList = append(c.posList, p)
+			}
+		}
+	}
+	return c.CommonConfig.Init()
+}
+
+// Validate validates config.
+func (c KWICConfig) Validate() error {
+	if err := c.CommonConfig.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.Query == "" && c.PosPattern == "":
+		return fmt.Errorf("no query\nSet -query <word> (or -pos <features>)")
+	case c.Output == "" && !c.ShowResult:
+		return fmt.Errorf("no output file\nSet -output <output file path> (or set -show option)")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package ripper
+
+import (
+	"fmt"
+)
+
+// PredictConfig contains options for 'predict' command.
+type PredictConfig struct {
+	CommonConfig
+
+	// model file path created by 'train' command
+	Model string
+	// model loaded from Model
+	NaiveBayes *NaiveBayesModel
+}
+
+// Init initializes config.
+func (c *PredictConfig) Init() error {
+	if c.Prefix == "" {
+		c.Prefix = defaultPrefix
+	}
+	if c.NaiveBayes == nil && c.Model != "" {
+		model, err := LoadNaiveBayesModel(c.Model)
+		if err != nil {
+			return err
+		}
+		c.NaiveBayes = model
+	}
+	if c.NaiveBayes != nil {
+		// use the same tokenizer settings with training
+		c.NaiveBayes.Tokenizer.Apply(&c.CommonConfig)
+	}
+	return c.CommonConfig.Init()
+}
+
+// Validate validates config.
+func (c PredictConfig) Validate() error {
+	if err := c.CommonConfig.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.NaiveBayes == nil:
+		return fmt.Errorf("no model file\nSet -model <model file path>")
+	case c.Output == "" && !c.ShowResult:
+		return fmt.Errorf("no output file\nSet -output <output file path> (or set -show option)")
+	}
+	return nil
+}
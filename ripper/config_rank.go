@@ -6,6 +6,15 @@ import (
 
 const (
 	defaultTopNumber = 100
+	defaultMaxGroups = 1000
+)
+
+// score types for ranking.
+const (
+	ScoreCount = "count"
+	ScoreDF    = "df"
+	ScoreTFIDF = "tfidf"
+	ScoreBM25  = "bm25"
 )
 
 // RankConfig contains options for 'rank' command.
@@ -23,6 +32,17 @@ type RankConfig struct {
 
 	// count as one word if the same word exists in a line.
 	UseUnique bool
+	// score type to sort the ranking (count, df, tfidf, bm25)
+	Score string
+
+	// column name to separate the ranking by its value
+	GroupBy string
+	// maximum number of groups, the rest of groups are merged into one.
+	// (default: 1000, negative value means unlimited)
+	MaxGroups int
+
+	// function to extract the terms to rank from raw text instead of the words (e.g. hashtags, domains)
+	TermExtractor func(rawText string) []string
 }
 
 // Init initializes config.
@@ -36,6 +56,12 @@ func (c *RankConfig) Init() error {
 	default:
 		c.TopNumber = defaultTopNumber
 	}
+	if c.Score == "" {
+		c.Score = ScoreCount
+	}
+	if c.MaxGroups == 0 {
+		c.MaxGroups = defaultMaxGroups
+	}
 
 	return c.CommonConfig.Init()
 }
@@ -49,5 +75,12 @@ func (c RankConfig) Validate() error {
 	if c.Output == "" && !c.ShowResult {
 		return fmt.Errorf("no output file\nSet -output <output file path> (or set -show option)")
 	}
+
+	switch c.Score {
+	case "", ScoreCount, ScoreDF, ScoreTFIDF, ScoreBM25:
+		// pass
+	default:
+		return fmt.Errorf("invalid score type: [%s]\nSet -score <count|df|tfidf|bm25>", c.Score)
+	}
 	return nil
 }
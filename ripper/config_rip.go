@@ -2,10 +2,29 @@ package ripper
 
 import (
 	"fmt"
+
+	"github.com/evalphobia/go-jp-text-ripper/tokenizer"
 )
 
 const defaultPrefix = "op_"
 
+// output formats for 'rip' command.
+const (
+	FormatTable    = "table"
+	FormatFastText = "fasttext"
+	FormatPlain    = "plain"
+)
+
+// units of the text for 'rip' command.
+const (
+	UnitLine     = "line"
+	UnitSentence = "sentence"
+)
+
+const (
+	fastTextLabelPrefix = "__label__"
+)
+
 // RipConfig contains options for 'rip' command.
 type RipConfig struct {
 	CommonConfig
@@ -27,6 +46,22 @@ type RipConfig struct {
 	StopWordLastPercent float64 // 0.0~1.0
 	// use counting as one word if the same word exists in a line
 	UseStopWordUnique bool
+
+	// output format (table, fasttext, plain)
+	Format string
+	// column name of the label for fasttext format
+	LabelColumn string
+	// convert words into lower case
+	UseLowercase bool
+
+	// unit of the text to output a row (line, sentence)
+	Unit string
+
+	// replaces the target column of the output (e.g. masked text for personal data).
+	// the original text is kept when it's nil.
+	TextMask func(text *TextData) string
+	// replaces the output word and returns true (e.g. person names for personal data).
+	WordMask func(token *tokenizer.Token) (string, bool)
 }
 
 // Init initializes config.
@@ -34,6 +69,12 @@ func (c *RipConfig) Init() error {
 	if c.Prefix == "" {
 		c.Prefix = defaultPrefix
 	}
+	if c.Format == "" {
+		c.Format = FormatTable
+	}
+	if c.Unit == "" {
+		c.Unit = UnitLine
+	}
 	return c.CommonConfig.Init()
 }
 
@@ -46,9 +87,41 @@ func (c RipConfig) Validate() error {
 	if c.Output == "" && !c.ShowResult && !c.Debug {
 		return fmt.Errorf("no output file\nSet -output <output file path> (or set -show option)")
 	}
+
+	switch c.Format {
+	case "", FormatTable, FormatPlain:
+		// pass
+	case FormatFastText:
+		if c.LabelColumn == "" {
+			return fmt.Errorf("no label column\nSet -label-column <label column name>")
+		}
+	default:
+		return fmt.Errorf("invalid format: [%s]\nSet -format <table|fasttext|plain>", c.Format)
+	}
+
+	switch c.Unit {
+	case "", UnitLine, UnitSentence:
+		// pass
+	default:
+		return fmt.Errorf("invalid unit: [%s]\nSet -unit <line|sentence>", c.Unit)
+	}
 	return nil
 }
 
+// UseSentenceUnit checks the text is separated into sentences or not.
+func (c RipConfig) UseSentenceUnit() bool {
+	return c.Unit == UnitSentence
+}
+
+// UsePlainFormat checks output is plain text without the original columns or not.
+func (c RipConfig) UsePlainFormat() bool {
+	switch c.Format {
+	case FormatFastText, FormatPlain:
+		return true
+	}
+	return false
+}
+
 // UseRankingForStopWord uses word frequency ranking as a stopword.
 func (c RipConfig) UseRankingForStopWord() bool {
 	switch {
@@ -0,0 +1,91 @@
+package ripper
+
+import (
+	"fmt"
+)
+
+const (
+	defaultTopicSize       = 10
+	defaultTopicIterations = 100
+	defaultTopicBeta       = 0.01
+	defaultTopicWordNumber = 10
+)
+
+// TopicsConfig contains options for 'topics' command.
+type TopicsConfig struct {
+	CommonConfig
+
+	// number of topics
+	TopicSize int
+	// number of iterations for Gibbs sampling
+	Iterations int
+	// seed for random number
+	Seed int64
+	// hyper parameter of document-topic distribution (default=50/TopicSize)
+	Alpha float64
+	// hyper parameter of topic-word distribution
+	Beta float64
+
+	// output file path of top words per topic
+	TopicOutput string
+	// number of top words per topic
+	TopicWordNumber int
+
+	// use ranking from the top N as stopword
+	StopWordTopNumber int
+	// use ranking from the top by percent as stopword
+	StopWordTopPercent float64 // 0.0~1.0
+	// use ranking from the last N as stopword
+	StopWordLastNumber int
+	// use ranking from the last by percent as stopword
+	StopWordLastPercent float64 // 0.0~1.0
+	// use counting as one word if the same word exists in a line
+	UseStopWordUnique bool
+}
+
+// Init initializes config.
+func (c *TopicsConfig) Init() error {
+	if c.Prefix == "" {
+		c.Prefix = defaultPrefix
+	}
+	if c.TopicSize <= 0 {
+		c.TopicSize = defaultTopicSize
+	}
+	if c.Iterations <= 0 {
+		c.Iterations = defaultTopicIterations
+	}
+	if c.Alpha <= 0 {
+		c.Alpha = 50.0 / float64(c.TopicSize)
+	}
+	if c.Beta <= 0 {
+		c.Beta = defaultTopicBeta
+	}
+	if c.TopicWordNumber <= 0 {
+		c.TopicWordNumber = defaultTopicWordNumber
+	}
+	return c.CommonConfig.Init()
+}
+
+// Validate validates config.
+func (c TopicsConfig) Validate() error {
+	if err := c.CommonConfig.Validate(); err != nil {
+		return err
+	}
+
+	if c.Output == "" && c.TopicOutput == "" && !c.ShowResult {
+		return fmt.Errorf("no output file\nSet -output <output file path> (or set -topic-output or -show option)")
+	}
+	return nil
+}
+
+// UseRankingForStopWord uses word frequency ranking as a stopword.
+func (c TopicsConfig) UseRankingForStopWord() bool {
+	switch {
+	case c.StopWordTopNumber > 0,
+		c.StopWordTopPercent > 0,
+		c.StopWordLastNumber > 0,
+		c.StopWordLastPercent > 0:
+		return true
+	}
+	return false
+}
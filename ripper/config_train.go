@@ -0,0 +1,58 @@
+package ripper
+
+import (
+	"fmt"
+)
+
+const (
+	defaultNaiveBayesAlpha = 1.0
+)
+
+// TrainConfig contains options for 'train' command.
+type TrainConfig struct {
+	CommonConfig
+
+	// column name of the label
+	LabelColumn string
+	// output file path of the model
+	ModelOutput string
+	// smoothing parameter (1.0=Laplace)
+	Alpha float64
+	// filter options to save in the model
+	Filter FilterSetting
+
+	// number of folds for cross validation (0=no evaluation)
+	KFold int
+	// seed for random number to split folds
+	Seed int64
+}
+
+// Init initializes config.
+func (c *TrainConfig) Init() error {
+	if c.Alpha <= 0 {
+		c.Alpha = defaultNaiveBayesAlpha
+	}
+	return c.CommonConfig.Init()
+}
+
+// Validate validates config.
+func (c TrainConfig) Validate() error {
+	if err := c.CommonConfig.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.LabelColumn == "":
+		return fmt.Errorf("no label column\nSet -label <label column name>")
+	case c.ModelOutput == "":
+		return fmt.Errorf("no model output file\nSet -model <model file path>")
+	case c.KFold == 1 || c.KFold < 0:
+		return fmt.Errorf("invalid fold number: [%d]\nSet -kfold <number greater than 1>", c.KFold)
+	}
+	return nil
+}
+
+// UseEvaluation checks cross validation is used or not.
+func (c TrainConfig) UseEvaluation() bool {
+	return c.KFold > 1
+}
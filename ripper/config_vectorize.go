@@ -0,0 +1,96 @@
+package ripper
+
+import (
+	"fmt"
+
+	"github.com/evalphobia/go-jp-text-ripper/writer"
+)
+
+// weight types for vectorize.
+const (
+	WeightCount  = "count"
+	WeightBinary = "binary"
+	WeightTFIDF  = "tfidf"
+)
+
+// VectorizeConfig contains options for 'vectorize' command.
+type VectorizeConfig struct {
+	CommonConfig
+
+	// output format of the matrix (mm, libsvm)
+	Format string
+	// weight of the matrix value (count, binary, tfidf)
+	Weight string
+	// column name of the label for LIBSVM format
+	LabelColumn string
+
+	// output file path of the vocabulary
+	VocabularyOutput string
+	// saved vocabulary file path to use instead of building from the input
+	Vocabulary string
+
+	// ignore words which document frequency is lower than this
+	MinDF int
+	// ignore words which document frequency ratio is higher than this
+	MaxDF float64 // 0.0~1.0
+	// use top N words by the frequency as the vocabulary
+	MaxFeatures int
+	// count as one word if the same word exists in a line.
+	UseUnique bool
+}
+
+// Init initializes config.
+func (c *VectorizeConfig) Init() error {
+	if c.Format == "" {
+		c.Format = writer.FormatMatrixMarket
+	}
+	if c.Weight == "" {
+		c.Weight = WeightCount
+	}
+	if c.MinDF <= 0 {
+		c.MinDF = 1
+	}
+	if c.MaxDF <= 0 {
+		c.MaxDF = 1.0
+	}
+	return c.CommonConfig.Init()
+}
+
+// Validate validates config.
+func (c VectorizeConfig) Validate() error {
+	if err := c.CommonConfig.Validate(); err != nil {
+		return err
+	}
+
+	if c.Output == "" {
+		return fmt.Errorf("no output file\nSet -output <output file path>")
+	}
+	if c.Vocabulary == "" && c.VocabularyOutput == "" {
+		return fmt.Errorf("no vocabulary file\nSet -vocab-output <output vocabulary file path> (or -vocab <saved vocabulary file path>)")
+	}
+
+	switch c.Format {
+	case writer.FormatMatrixMarket, writer.FormatLIBSVM:
+		// pass
+	default:
+		return fmt.Errorf("invalid format: [%s]\nSet -format <mm|libsvm>", c.Format)
+	}
+	switch c.Weight {
+	case WeightCount, WeightBinary, WeightTFIDF:
+		// pass
+	default:
+		return fmt.Errorf("invalid weight: [%s]\nSet -weight <count|binary|tfidf>", c.Weight)
+	}
+	return nil
+}
+
+// getRankConfig returns RankConfig to build the vocabulary.
+func (c VectorizeConfig) getRankConfig() RankConfig {
+	common := c.CommonConfig
+	common.Output = ""
+	return RankConfig{
+		CommonConfig: common,
+		UseUnique:    c.UseUnique,
+		Score:        ScoreCount,
+	}
+}
@@ -0,0 +1,146 @@
+package ripper
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// ldaModel is Latent Dirichlet Allocation model trained by collapsed Gibbs sampling.
+type ldaModel struct {
+	topicSize int
+	alpha     float64
+	beta      float64
+	rnd       *rand.Rand
+
+	vocab    []string
+	vocabMap map[string]int
+
+	docs   [][]int // word ids of each document
+	topics [][]int // topic of each word in documents
+
+	docTopic   [][]int // count of words assigned to the topic in the document
+	topicWord  [][]int // count of the word assigned to the topic
+	topicTotal []int   // count of words assigned to the topic
+}
+
+func newLDAModel(topicSize int, alpha, beta float64, seed int64) *ldaModel {
+	return &ldaModel{
+		topicSize: topicSize,
+		alpha:     alpha,
+		beta:      beta,
+		/* #nosec G404 */
+		rnd:      rand.New(rand.NewSource(seed)),
+		vocabMap: make(map[string]int, 1024),
+	}
+}
+
+// addDocument adds words of a document.
+func (m *ldaModel) addDocument(words []string) {
+	doc := make([]int, len(words))
+	for i, w := range words {
+		id, ok := m.vocabMap[w]
+		if !ok {
+			id = len(m.vocab)
+			m.vocabMap[w] = id
+			m.vocab = append(m.vocab, w)
+		}
+		doc[i] = id
+	}
+	m.docs = append(m.docs, doc)
+}
+
+// init assigns random topics to all of the words.
+func (m *ldaModel) init() {
+	k := m.topicSize
+	m.topicWord = make([][]int, k)
+	for i := range m.topicWord {
+		m.topicWord[i] = make([]int, len(m.vocab))
+	}
+	m.topicTotal = make([]int, k)
+	m.docTopic = make([][]int, len(m.docs))
+	m.topics = make([][]int, len(m.docs))
+
+	for d, doc := range m.docs {
+		m.docTopic[d] = make([]int, k)
+		m.topics[d] = make([]int, len(doc))
+		for i, w := range doc {
+			z := m.rnd.Intn(k)
+			m.topics[d][i] = z
+			m.docTopic[d][z]++
+			m.topicWord[z][w]++
+			m.topicTotal[z]++
+		}
+	}
+}
+
+// iterate runs a sweep of Gibbs sampling over all of the words.
+func (m *ldaModel) iterate() {
+	k := m.topicSize
+	vBeta := float64(len(m.vocab)) * m.beta
+	prob := make([]float64, k)
+
+	for d, doc := range m.docs {
+		dt := m.docTopic[d]
+		for i, w := range doc {
+			z := m.topics[d][i]
+			dt[z]--
+			m.topicWord[z][w]--
+			m.topicTotal[z]--
+
+			sum := 0.0
+			for t := 0; t < k; t++ {
+				sum += (float64(dt[t]) + m.alpha) *
+					(float64(m.topicWord[t][w]) + m.beta) /
+					(float64(m.topicTotal[t]) + vBeta)
+				prob[t] = sum
+			}
+			u := m.rnd.Float64() * sum
+			z = sort.SearchFloat64s(prob, u)
+			if z >= k {
+				z = k - 1
+			}
+
+			m.topics[d][i] = z
+			dt[z]++
+			m.topicWord[z][w]++
+			m.topicTotal[z]++
+		}
+	}
+}
+
+// docTopicDistribution returns topic distribution of the document.
+func (m *ldaModel) docTopicDistribution(d int) []float64 {
+	k := m.topicSize
+	dist := make([]float64, k)
+	total := float64(len(m.docs[d])) + float64(k)*m.alpha
+	for t := 0; t < k; t++ {
+		dist[t] = (float64(m.docTopic[d][t]) + m.alpha) / total
+	}
+	return dist
+}
+
+// topWords returns the top N words of the topic.
+func (m *ldaModel) topWords(topic, n int) []topicWordProb {
+	vBeta := float64(len(m.vocab)) * m.beta
+	total := float64(m.topicTotal[topic]) + vBeta
+
+	list := make([]topicWordProb, len(m.vocab))
+	for w, count := range m.topicWord[topic] {
+		list[w] = topicWordProb{
+			word: m.vocab[w],
+			prob: (float64(count) + m.beta) / total,
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].prob > list[j].prob
+	})
+	if len(list) > n {
+		list = list[:n]
+	}
+	return list
+}
+
+type topicWordProb struct {
+	word string
+	prob float64
+}
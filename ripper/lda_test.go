@@ -0,0 +1,75 @@
+package ripper
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestLDAModel(t *testing.T) {
+	docs := [][]string{
+		{"cat", "dog", "pet", "cat", "dog"},
+		{"dog", "pet", "cat", "pet"},
+		{"cat", "pet", "dog", "dog"},
+		{"stock", "bank", "money", "bank"},
+		{"money", "stock", "bank", "stock"},
+		{"bank", "money", "money", "stock"},
+	}
+
+	m := newLDAModel(2, 0.1, 0.01, 1)
+	for _, words := range docs {
+		m.addDocument(words)
+	}
+	m.init()
+	for i := 0; i < 100; i++ {
+		m.iterate()
+	}
+
+	for d := range docs {
+		sum := 0.0
+		for _, p := range m.docTopicDistribution(d) {
+			sum += p
+		}
+		if !almostEqual(sum, 1) {
+			t.Errorf("sum of docTopicDistribution(%d) = %v, want 1", d, sum)
+		}
+	}
+
+	// each topic has the words of either group
+	groups := make([]string, 2)
+	for topic := range groups {
+		words := make([]string, 0, 3)
+		for _, v := range m.topWords(topic, 3) {
+			words = append(words, v.word)
+		}
+		sort.Strings(words)
+		groups[topic] = words[0] + "," + words[1] + "," + words[2]
+	}
+	sort.Strings(groups)
+	expected := []string{"bank,money,stock", "cat,dog,pet"}
+	if !equalStrings(groups, expected) {
+		t.Errorf("topWords() = %v, want %v", groups, expected)
+	}
+
+	// the documents of the same group have the same major topic
+	major := func(d int) int {
+		dist := m.docTopicDistribution(d)
+		if dist[0] > dist[1] {
+			return 0
+		}
+		return 1
+	}
+	for d := range docs {
+		group := d / 3
+		if major(d) != major(group*3) || major(d) == major((1-group)*3) {
+			t.Errorf("major topic of document %d = %d, want the same as the group %d", d, major(d), group)
+		}
+	}
+
+	if got := m.topWords(0, 100); len(got) != len(m.vocab) {
+		t.Errorf("len(topWords(0, 100)) = %d, want %d", len(got), len(m.vocab))
+	}
+	if p := m.topWords(0, 1)[0].prob; p <= 0 || p >= 1 || math.IsNaN(p) {
+		t.Errorf("topWords(0, 1)[0].prob = %v, want 0 ~ 1", p)
+	}
+}
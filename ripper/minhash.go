@@ -0,0 +1,184 @@
+package ripper
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+)
+
+// minHasher creates MinHash signature from words.
+type minHasher struct {
+	shingleSize int
+	seeds       []uint64
+}
+
+func newMinHasher(shingleSize, hashSize int, seed int64) *minHasher {
+	/* #nosec G404 */
+	rnd := rand.New(rand.NewSource(seed))
+	seeds := make([]uint64, hashSize)
+	for i := range seeds {
+		seeds[i] = rnd.Uint64()
+	}
+	return &minHasher{
+		shingleSize: shingleSize,
+		seeds:       seeds,
+	}
+}
+
+// signature returns MinHash signature of word shingles.
+// it returns nil when words are empty.
+func (h *minHasher) signature(words []string) []uint32 {
+	if len(words) == 0 {
+		return nil
+	}
+
+	sig := make([]uint32, len(h.seeds))
+	for i := range sig {
+		sig[i] = math.MaxUint32
+	}
+
+	size := h.shingleSize
+	if len(words) < size {
+		size = len(words)
+	}
+	for i := 0; i+size <= len(words); i++ {
+		x := hashString(strings.Join(words[i:i+size], "\x00"))
+		for j, seed := range h.seeds {
+			if v := uint32(mix64(x ^ seed)); v < sig[j] {
+				sig[j] = v
+			}
+		}
+	}
+	return sig
+}
+
+// similarity returns estimated jaccard similarity from signatures.
+func similarity(a, b []uint32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(a))
+}
+
+// bandKeys returns the keys of LSH buckets from signature.
+func bandKeys(sig []uint32, bandSize int) []uint64 {
+	rows := len(sig) / bandSize
+	keys := make([]uint64, bandSize)
+	buf := make([]byte, 4)
+	for b := 0; b < bandSize; b++ {
+		h := fnv.New64a()
+		binary.LittleEndian.PutUint32(buf, uint32(b))
+		_, _ = h.Write(buf)
+		for _, v := range sig[b*rows : (b+1)*rows] {
+			binary.LittleEndian.PutUint32(buf, v)
+			_, _ = h.Write(buf)
+		}
+		keys[b] = h.Sum64()
+	}
+	return keys
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = io.WriteString(h, s)
+	return h.Sum64()
+}
+
+// mix64 is finalizer of splitmix64.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// signatureStore saves signatures into temporary file to keep memory usage low.
+type signatureStore struct {
+	fp       *os.File
+	hashSize int
+	buf      []byte
+}
+
+func newSignatureStore(hashSize int) (*signatureStore, error) {
+	fp, err := ioutil.TempFile("", "go-jp-text-ripper-minhash-")
+	if err != nil {
+		return nil, err
+	}
+	return &signatureStore{
+		fp:       fp,
+		hashSize: hashSize,
+		buf:      make([]byte, hashSize*4),
+	}, nil
+}
+
+// put saves signature of the row (first=0).
+func (s *signatureStore) put(row int, sig []uint32) error {
+	for i, v := range sig {
+		binary.LittleEndian.PutUint32(s.buf[i*4:], v)
+	}
+	_, err := s.fp.WriteAt(s.buf, int64(row)*int64(len(s.buf)))
+	return err
+}
+
+// get loads signature of the row (first=0).
+func (s *signatureStore) get(row int) ([]uint32, error) {
+	if _, err := s.fp.ReadAt(s.buf, int64(row)*int64(len(s.buf))); err != nil {
+		return nil, err
+	}
+	sig := make([]uint32, s.hashSize)
+	for i := range sig {
+		sig[i] = binary.LittleEndian.Uint32(s.buf[i*4:])
+	}
+	return sig, nil
+}
+
+// Close closes and removes the temporary file.
+func (s *signatureStore) Close() error {
+	err := s.fp.Close()
+	if rerr := os.Remove(s.fp.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// unionFind is disjoint set of rows. the root is the first row of the set.
+type unionFind struct {
+	parent []uint32
+}
+
+func (u *unionFind) add() {
+	u.parent = append(u.parent, uint32(len(u.parent)))
+}
+
+func (u *unionFind) find(x int) int {
+	for int(u.parent[x]) != x {
+		u.parent[x] = u.parent[u.parent[x]]
+		x = int(u.parent[x])
+	}
+	return x
+}
+
+func (u *unionFind) union(a, b int) {
+	ra, rb := u.find(a), u.find(b)
+	switch {
+	case ra == rb:
+		return
+	case ra < rb:
+		u.parent[rb] = uint32(ra)
+	default:
+		u.parent[ra] = uint32(rb)
+	}
+}
@@ -0,0 +1,113 @@
+package ripper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/evalphobia/go-jp-text-ripper/log"
+)
+
+func TestMinHasherSignature(t *testing.T) {
+	h := newMinHasher(2, 64, 1)
+	tests := []struct {
+		a, b     []string
+		minScore float64
+		maxScore float64
+	}{
+		{[]string{"a", "b", "c", "d"}, []string{"a", "b", "c", "d"}, 1, 1},
+		// shorter than the shingle size
+		{[]string{"a"}, []string{"a"}, 1, 1},
+		{[]string{"a", "b", "c", "d"}, []string{"w", "x", "y", "z"}, 0, 0.1},
+		// jaccard similarity of shingles is 2/4
+		{[]string{"a", "b", "c", "d"}, []string{"a", "b", "c", "x"}, 0.3, 0.7},
+		{[]string{}, []string{}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		score := similarity(h.signature(tt.a), h.signature(tt.b))
+		if score < tt.minScore || score > tt.maxScore {
+			t.Errorf("similarity(%v, %v) = %v, want %v ~ %v", tt.a, tt.b, score, tt.minScore, tt.maxScore)
+		}
+	}
+}
+
+func TestBandKeys(t *testing.T) {
+	a := []uint32{1, 2, 3, 4, 5, 6}
+	b := []uint32{1, 2, 3, 4, 5, 7}
+
+	keysA := bandKeys(a, 3)
+	keysB := bandKeys(b, 3)
+	if len(keysA) != 3 {
+		t.Fatalf("len(bandKeys(%v, 3)) = %d, want 3", a, len(keysA))
+	}
+	for i, want := range []bool{true, true, false} {
+		if got := keysA[i] == keysB[i]; got != want {
+			t.Errorf("bandKeys(%v)[%d] == bandKeys(%v)[%d] is %v, want %v", a, i, b, i, got, want)
+		}
+	}
+
+	// the same values in the different bands have the different keys
+	if c := bandKeys([]uint32{1, 1}, 2); c[0] == c[1] {
+		t.Errorf("bandKeys([1 1], 2) = %v, want different keys", c)
+	}
+}
+
+func TestUnionFind(t *testing.T) {
+	u := &unionFind{}
+	for i := 0; i < 6; i++ {
+		u.add()
+	}
+	u.union(4, 2)
+	u.union(2, 5)
+	u.union(1, 3)
+	u.union(3, 5)
+
+	roots := make([]int, 6)
+	for i := range roots {
+		roots[i] = u.find(i)
+	}
+	// the root is the first row of the set
+	expected := []int{0, 1, 1, 1, 1, 1}
+	if !reflect.DeepEqual(roots, expected) {
+		t.Errorf("find() = %v, want %v", roots, expected)
+	}
+}
+
+func TestDedupProcessorGetClusters(t *testing.T) {
+	path := writeTestFile(t, "input.csv", "id,text\n"+
+		"1,今日は東京で良い天気が続いています\n"+
+		"2,明日の大阪は午後から雨が降るでしょう\n"+
+		"3,今日は東京で良い天気が続いています\n"+
+		"4,\n"+
+		"5,\n"+
+		"6,明日の大阪は午後から雨が降るでしょう\n")
+
+	r, err := NewDedupProcessor(DedupConfig{
+		CommonConfig: CommonConfig{Input: path, Column: "text", Logger: log.DefaultLogger},
+		ShingleSize:  2,
+		HashSize:     16,
+		BandSize:     4,
+		Threshold:    0.8,
+	})
+	if err != nil {
+		t.Fatalf("NewDedupProcessor() err = %v", err)
+	}
+	defer r.Close()
+	if err := r.ReadTargetHeader(); err != nil {
+		t.Fatalf("ReadTargetHeader() err = %v", err)
+	}
+
+	clusters, err := r.GetClusters()
+	if err != nil {
+		t.Fatalf("GetClusters() err = %v", err)
+	}
+	roots := make([]int, len(clusters.parent))
+	for i := range roots {
+		roots[i] = clusters.find(i)
+	}
+	// empty text is not treated as duplicate
+	expected := []int{0, 1, 0, 3, 4, 1}
+	if !reflect.DeepEqual(roots, expected) {
+		t.Errorf("GetClusters() = %v, want %v", roots, expected)
+	}
+}
@@ -0,0 +1,251 @@
+package ripper
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"sort"
+)
+
+// NaiveBayesModel is multinomial Naive Bayes classifier.
+type NaiveBayesModel struct {
+	Version   string           `json:"version"`
+	Alpha     float64          `json:"alpha"`
+	Tokenizer TokenizerSetting `json:"tokenizer"`
+	Labels    []string         `json:"labels"`
+	DocCount  map[string]int   `json:"doc_count"`
+	WordTotal map[string]int   `json:"word_total"`
+	WordCount map[string][]int `json:"word_count"` // word => count of each label
+	labelIdx  map[string]int   `json:"-"`
+}
+
+// TokenizerSetting is tokenizer options saved in the model.
+type TokenizerSetting struct {
+	MinLetterSize   int           `json:"min_letter_size"`
+	StopWords       []string      `json:"stop_words"`
+	UseOriginalForm bool          `json:"use_original_form"`
+	UseNoun         bool          `json:"use_noun"`
+	UseVerb         bool          `json:"use_verb"`
+	UseAdjective    bool          `json:"use_adjective"`
+	UseNeologd      bool          `json:"use_neologd"`
+	UseNFKC         bool          `json:"use_nfkc"`
+	Dictionary      string        `json:"dictionary,omitempty"`
+	Filter          FilterSetting `json:"filter"`
+}
+
+// FilterSetting is prefilter and key filter options saved in the model.
+// the values are the same as the command line options.
+type FilterSetting struct {
+	Markup           string `json:"markup,omitempty"`
+	UseProtectEntity bool   `json:"use_protect_entity,omitempty"`
+	UseEmojiName     bool   `json:"use_emoji_name,omitempty"`
+	EmojiDic         string `json:"emoji_dic,omitempty"`
+	Number           string `json:"number,omitempty"`
+	Squash           int    `json:"squash,omitempty"`
+	Laughter         string `json:"laughter,omitempty"`
+	Itaiji           string `json:"itaiji,omitempty"`
+	ItaijiDic        string `json:"itaiji_dic,omitempty"`
+	Fold             string `json:"fold,omitempty"`
+}
+
+// NewTokenizerSetting returns TokenizerSetting from the config.
+func NewTokenizerSetting(c CommonConfig, f FilterSetting) TokenizerSetting {
+	return TokenizerSetting{
+		MinLetterSize:   c.MinLetterSize,
+		StopWords:       c.StopWords,
+		UseOriginalForm: c.UseOriginalForm,
+		UseNoun:         c.UseNoun,
+		UseVerb:         c.UseVerb,
+		UseAdjective:    c.UseAdjective,
+		UseNeologd:      c.UseNeologd,
+		UseNFKC:         c.UseNFKC,
+		Dictionary:      c.Dictionary,
+		Filter:          f,
+	}
+}
+
+// Apply sets the tokenizer options into the config.
+// PreFilters and KeyFilters are not changed, so set them from UseNeologd, UseNFKC and Filter.
+func (s TokenizerSetting) Apply(c *CommonConfig) {
+	c.MinLetterSize = s.MinLetterSize
+	c.StopWordPath = ""
+	c.StopWords = s.StopWords
+	c.UseOriginalForm = s.UseOriginalForm
+	c.UseNoun = s.UseNoun
+	c.UseVerb = s.UseVerb
+	c.UseAdjective = s.UseAdjective
+	c.UseNeologd = s.UseNeologd
+	c.UseNFKC = s.UseNFKC
+	c.Dictionary = s.Dictionary
+}
+
+func newNaiveBayesModel(alpha float64, setting TokenizerSetting) *NaiveBayesModel {
+	return &NaiveBayesModel{
+		Alpha:     alpha,
+		Tokenizer: setting,
+		DocCount:  make(map[string]int),
+		WordTotal: make(map[string]int),
+		WordCount: make(map[string][]int),
+		labelIdx:  make(map[string]int),
+	}
+}
+
+// LoadNaiveBayesModel loads the model from file.
+func LoadNaiveBayesModel(path string) (*NaiveBayesModel, error) {
+	/* #nosec G304 */
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &NaiveBayesModel{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, err
+	}
+	m.labelIdx = make(map[string]int, len(m.Labels))
+	for i, l := range m.Labels {
+		m.labelIdx[l] = i
+	}
+	return m, nil
+}
+
+// Save writes the model into file.
+func (m *NaiveBayesModel) Save(path string) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// Add trains the model by words of a document.
+func (m *NaiveBayesModel) Add(label string, words []string) {
+	idx, ok := m.labelIdx[label]
+	if !ok {
+		idx = len(m.Labels)
+		m.labelIdx[label] = idx
+		m.Labels = append(m.Labels, label)
+		for w, counts := range m.WordCount {
+			m.WordCount[w] = append(counts, 0)
+		}
+	}
+
+	m.DocCount[label]++
+	m.WordTotal[label] += len(words)
+	for _, w := range words {
+		counts, ok := m.WordCount[w]
+		if !ok {
+			counts = make([]int, len(m.Labels))
+			m.WordCount[w] = counts
+		}
+		counts[idx]++
+	}
+}
+
+// Predict returns the label of the highest probability and the probability.
+func (m *NaiveBayesModel) Predict(words []string) (label string, score float64) {
+	if len(m.Labels) == 0 {
+		return "", 0
+	}
+
+	logProbs := m.logProbs(words)
+	best := 0
+	for i, p := range logProbs {
+		if p > logProbs[best] {
+			best = i
+		}
+	}
+
+	// softmax
+	sum := 0.0
+	for _, p := range logProbs {
+		sum += math.Exp(p - logProbs[best])
+	}
+	return m.Labels[best], 1 / sum
+}
+
+// logProbs returns log probabilities of each label (not normalized).
+func (m *NaiveBayesModel) logProbs(words []string) []float64 {
+	totalDocs := 0
+	for _, c := range m.DocCount {
+		totalDocs += c
+	}
+	vocabSize := float64(len(m.WordCount))
+
+	probs := make([]float64, len(m.Labels))
+	for i, label := range m.Labels {
+		p := math.Log(float64(m.DocCount[label]) / float64(totalDocs))
+		denom := math.Log(float64(m.WordTotal[label]) + m.Alpha*vocabSize)
+		for _, w := range words {
+			counts, ok := m.WordCount[w]
+			if !ok {
+				// ignore unknown word
+				continue
+			}
+			p += math.Log(float64(counts[i])+m.Alpha) - denom
+		}
+		probs[i] = p
+	}
+	return probs
+}
+
+// classificationReport is evaluation result of the classifier.
+type classificationReport struct {
+	labels    []string
+	confusion map[string]map[string]int // actual => predicted => count
+}
+
+func newClassificationReport() *classificationReport {
+	return &classificationReport{
+		confusion: make(map[string]map[string]int),
+	}
+}
+
+func (r *classificationReport) add(actual, predicted string) {
+	for _, l := range []string{actual, predicted} {
+		if _, ok := r.confusion[l]; !ok {
+			r.confusion[l] = make(map[string]int)
+			r.labels = append(r.labels, l)
+			sort.Strings(r.labels)
+		}
+	}
+	r.confusion[actual][predicted]++
+}
+
+// metrics returns precision, recall, f1 and support of the label.
+func (r *classificationReport) metrics(label string) (precision, recall, f1 float64, support int) {
+	tp := r.confusion[label][label]
+	predicted := 0
+	for _, l := range r.labels {
+		predicted += r.confusion[l][label]
+		support += r.confusion[label][l]
+	}
+	if predicted > 0 {
+		precision = float64(tp) / float64(predicted)
+	}
+	if support > 0 {
+		recall = float64(tp) / float64(support)
+	}
+	if precision+recall > 0 {
+		f1 = 2 * precision * recall / (precision + recall)
+	}
+	return precision, recall, f1, support
+}
+
+// accuracy returns ratio of the correct prediction.
+func (r *classificationReport) accuracy() float64 {
+	correct, total := 0, 0
+	for _, actual := range r.labels {
+		for _, predicted := range r.labels {
+			count := r.confusion[actual][predicted]
+			total += count
+			if actual == predicted {
+				correct += count
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(correct) / float64(total)
+}
@@ -0,0 +1,123 @@
+package ripper
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/evalphobia/go-jp-text-ripper/log"
+)
+
+func TestNaiveBayesModelPredict(t *testing.T) {
+	m := newNaiveBayesModel(1, TokenizerSetting{})
+	if label, score := m.Predict([]string{"good"}); label != "" || score != 0 {
+		t.Errorf("Predict() on empty model = (%q, %v), want (\"\", 0)", label, score)
+	}
+
+	m.Add("pos", []string{"good", "great"})
+	m.Add("pos", []string{"good"})
+	m.Add("neg", []string{"bad"})
+
+	// the count of the added label is appended to the known words
+	if got := m.WordCount["good"]; !reflect.DeepEqual(got, []int{2, 0}) {
+		t.Errorf("WordCount[good] = %v, want [2 0]", got)
+	}
+
+	tests := []struct {
+		words []string
+		label string
+		score float64
+	}{
+		// pos: 2/3 * 3/6, neg: 1/3 * 1/4
+		{[]string{"good"}, "pos", 0.8},
+		// pos: 2/3 * 1/6, neg: 1/3 * 2/4
+		{[]string{"bad"}, "neg", 0.6},
+		// unknown word is ignored, so the prior is used
+		{[]string{"unknown"}, "pos", 2.0 / 3},
+		{nil, "pos", 2.0 / 3},
+	}
+
+	path := filepath.Join(t.TempDir(), "model.json")
+	if err := m.Save(path); err != nil {
+		t.Fatalf("Save() err = %v", err)
+	}
+	loaded, err := LoadNaiveBayesModel(path)
+	if err != nil {
+		t.Fatalf("LoadNaiveBayesModel() err = %v", err)
+	}
+
+	for _, model := range []*NaiveBayesModel{m, loaded} {
+		for _, tt := range tests {
+			label, score := model.Predict(tt.words)
+			if label != tt.label || !almostEqual(score, tt.score) {
+				t.Errorf("Predict(%v) = (%q, %v), want (%q, %v)", tt.words, label, score, tt.label, tt.score)
+			}
+		}
+	}
+}
+
+func TestClassificationReport(t *testing.T) {
+	r := newClassificationReport()
+	r.add("a", "a")
+	r.add("a", "a")
+	r.add("a", "b")
+	r.add("b", "b")
+
+	tests := []struct {
+		label     string
+		precision float64
+		recall    float64
+		f1        float64
+		support   int
+	}{
+		{"a", 1, 2.0 / 3, 0.8, 3},
+		{"b", 0.5, 1, 2.0 / 3, 1},
+		{"c", 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		precision, recall, f1, support := r.metrics(tt.label)
+		if !almostEqual(precision, tt.precision) || !almostEqual(recall, tt.recall) || !almostEqual(f1, tt.f1) || support != tt.support {
+			t.Errorf("metrics(%q) = (%v, %v, %v, %d), want (%v, %v, %v, %d)",
+				tt.label, precision, recall, f1, support, tt.precision, tt.recall, tt.f1, tt.support)
+		}
+	}
+
+	if got := r.accuracy(); !almostEqual(got, 0.75) {
+		t.Errorf("accuracy() = %v, want 0.75", got)
+	}
+	if got := newClassificationReport().accuracy(); got != 0 {
+		t.Errorf("accuracy() on empty report = %v, want 0", got)
+	}
+}
+
+func TestTrainProcessorCrossValidate(t *testing.T) {
+	docs := []labeledDocument{
+		{"pos", []string{"good", "nice"}},
+		{"neg", []string{"bad", "poor"}},
+		{"pos", []string{"good", "great"}},
+		{"neg", []string{"bad", "awful"}},
+		{"pos", []string{"nice", "great"}},
+		{"neg", []string{"poor", "awful"}},
+	}
+
+	for _, k := range []int{2, 3} {
+		r := &TrainProcessor{Config: TrainConfig{
+			CommonConfig: CommonConfig{Logger: log.DefaultLogger},
+			Alpha:        1,
+			KFold:        k,
+			Seed:         1,
+		}}
+		report := r.crossValidate(docs)
+
+		// each document is predicted once
+		_, _, _, pos := report.metrics("pos")
+		_, _, _, neg := report.metrics("neg")
+		if pos != 3 || neg != 3 {
+			t.Errorf("crossValidate() with %d folds support = (%d, %d), want (3, 3)", k, pos, neg)
+		}
+		if got := report.accuracy(); !almostEqual(got, 1) {
+			t.Errorf("crossValidate() with %d folds accuracy = %v, want 1", k, got)
+		}
+	}
+}
@@ -4,6 +4,8 @@ package ripper
 type Plugin struct {
 	Title string
 	Fn    func(*TextData) string
+	// input columns to read by TextData.GetColumn, they are validated with the header
+	Columns []string
 }
 
 // PostFilter outputs extra column with custom logic after plugin process
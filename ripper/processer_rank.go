@@ -3,8 +3,21 @@ package ripper
 import (
 	"fmt"
 	"io"
+	"math"
 	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/evalphobia/go-jp-text-ripper/reader"
+)
+
+// otherGroupName is used for the lines of the groups over the limit.
+const otherGroupName = "__others__"
+
+// parameters for BM25.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
 )
 
 // DoRank creates *RankProcessor from config and run it.
@@ -30,10 +43,41 @@ func DoRank(conf RankConfig) error {
 	return r.DoWithProgress()
 }
 
+// GetCorpusRank reads the input and gets word frequency ranking of the corpus (e.g. for stop words and idf).
+func GetCorpusRank(c RankConfig) (RankResult, error) {
+	// the ranking is not written into file
+	c.Output = ""
+	rp, err := NewRankProcessor(c)
+	if err != nil {
+		return RankResult{}, err
+	}
+	defer rp.Close()
+
+	if err := rp.ReadHeader(); err != nil {
+		return RankResult{}, err
+	}
+	return rp.GetRank()
+}
+
+// GetCorpusIDF reads the input and gets idf of each word in the corpus.
+func GetCorpusIDF(c CommonConfig) (map[string]float64, error) {
+	if err := c.Init(); err != nil {
+		return nil, err
+	}
+	rank, err := GetCorpusRank(RankConfig{
+		CommonConfig: c,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rank.GetIDF(), nil
+}
+
 // RankProcessor is struct for word ranking.
 type RankProcessor struct {
 	*CommonProcessor
-	Config RankConfig
+	Config     RankConfig
+	groupIndex int
 }
 
 // NewRankProcessor returns initialized RankProcessor.
@@ -46,6 +90,7 @@ func NewRankProcessor(c RankConfig) (*RankProcessor, error) {
 	r := &RankProcessor{
 		CommonProcessor: common,
 		Config:          c,
+		groupIndex:      -1,
 	}
 	return r, nil
 }
@@ -53,12 +98,31 @@ func NewRankProcessor(c RankConfig) (*RankProcessor, error) {
 // ReadHeader reads header columns and sets target column.
 func (r *RankProcessor) ReadHeader() error {
 	c := r.Config
+	var err error
 	switch {
 	case c.ColumnNumber > 0:
-		return r.CommonProcessor.ReadHeaderWithIndex(c.ColumnNumber - 1)
+		err = r.CommonProcessor.ReadHeaderWithIndex(c.ColumnNumber - 1)
 	default:
-		return r.readHeaderByName(c.Column)
+		err = r.readHeaderByName(c.Column)
+	}
+	if err != nil {
+		return err
+	}
+	return r.setGroupIndex(c.GroupBy)
+}
+
+// setGroupIndex sets index of the group column.
+func (r *RankProcessor) setGroupIndex(col string) error {
+	r.groupIndex = -1
+	if col == "" {
+		return nil
+	}
+
+	r.groupIndex = r.GetColumnIndex(col)
+	if r.groupIndex < 0 {
+		return fmt.Errorf("cannnot find group column name in header: col:[%s] headers:[%+v]", col, r.inputHeader)
 	}
+	return nil
 }
 
 // readHeaderByName reads header columns and check target column is existed or not.
@@ -92,13 +156,18 @@ func (r *RankProcessor) WriteHeader() error {
 		}
 	}
 
-	r.outputHeader = []string{
+	r.outputHeader = []string{}
+	if r.groupIndex >= 0 {
+		r.outputHeader = append(r.outputHeader, "group")
+	}
+	r.outputHeader = append(r.outputHeader,
 		"type",
 		"rank",
 		"word",
 		"countN",
 		"countP",
-	}
+	)
+	r.outputHeader = append(r.outputHeader, scoreColumns(r.Config.Score)...)
 
 	// write to file
 	return r.w.Write(r.outputHeader)
@@ -128,6 +197,10 @@ func (r *RankProcessor) Do() error {
 	c := r.Config
 	logger := c.Logger
 
+	if r.groupIndex >= 0 {
+		return r.doGroup()
+	}
+
 	rank, err := r.GetRank()
 	if err != nil {
 		return err
@@ -135,21 +208,67 @@ func (r *RankProcessor) Do() error {
 
 	logger.Infof("Do", "Total Words:%d", rank.GetTotalWordSize())
 
-	if err := r.output("top", rank.TopList); err != nil {
+	if err := r.output("", "top", rank.TopList); err != nil {
+		return err
+	}
+	return r.output("", "last", rank.LastList)
+}
+
+// doGroup processes word frequency ranking for each group.
+func (r *RankProcessor) doGroup() error {
+	logger := r.Config.Logger
+
+	ranks, groups, err := r.GetGroupRank()
+	if err != nil {
 		return err
 	}
-	return r.output("last", rank.LastList)
+
+	logger.Infof("doGroup", "Total Groups:%d", len(groups))
+	for _, g := range groups {
+		rank := ranks[g]
+		if err := r.output(g, "top", rank.TopList); err != nil {
+			return err
+		}
+		if err := r.output(g, "last", rank.LastList); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // GetRank gets result of word freqency ranking.
 func (r *RankProcessor) GetRank() (RankResult, error) {
-	c := r.Config
-
 	rank, err := r.getRank()
 	if err != nil {
 		return rank, err
 	}
+	return r.filterRank(rank)
+}
+
+// GetGroupRank gets results of word freqency ranking for each group value.
+func (r *RankProcessor) GetGroupRank() (map[string]RankResult, []string, error) {
+	c := r.Config
+
+	counters, groups, err := r.countWords(r.groupIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ranks := make(map[string]RankResult, len(counters))
+	for _, g := range groups {
+		rank, err := r.filterRank(counters[g].result(c.Score))
+		if err != nil {
+			return nil, nil, err
+		}
+		ranks[g] = rank
+	}
+	return ranks, groups, nil
+}
 
+// filterRank sets top and last list into the ranking.
+func (r *RankProcessor) filterRank(rank RankResult) (RankResult, error) {
+	c := r.Config
+	var err error
 	rank.TopList, err = r.FilterByRank(rank, c.TopNumber, c.TopPercent, func(i int) int {
 		return i
 	})
@@ -165,26 +284,49 @@ func (r *RankProcessor) GetRank() (RankResult, error) {
 }
 
 func (r *RankProcessor) getRank() (RankResult, error) {
+	counter, err := r.countAll()
+	if err != nil {
+		return RankResult{}, err
+	}
+	return counter.result(r.Config.Score), nil
+}
+
+// countAll reads lines and counts words of all of the lines.
+func (r *RankProcessor) countAll() (*rankCounter, error) {
+	counters, _, err := r.countWords(-1)
+	if err != nil {
+		return nil, err
+	}
+	counter, ok := counters[""]
+	if !ok {
+		return nil, fmt.Errorf("no word is counted")
+	}
+	return counter, nil
+}
+
+// countWords reads lines and counts words for each group.
+// when groupIdx is negative, all of the lines are counted in the group of empty name.
+func (r *RankProcessor) countWords(groupIdx int) (counters map[string]*rankCounter, groups []string, err error) {
 	defer r.r.Close()
 	c := r.Config
 	logger := c.Logger
 	idx := r.columnIndex
-	tok := r.tok
 
 	lastLineNo := 1
 	lastLineText := ""
 	defer func() {
-		err := recover()
-		if err == nil {
+		rec := recover()
+		if rec == nil {
 			return
 		}
 		logger.Errorf("GetRank", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
+		counters = nil
+		groups = nil
+		err = fmt.Errorf("unknown error occurred on Line:[%d] err:[%v]", lastLineNo, rec)
 	}()
 
-	result := RankResult{}
-	resultMap := make(map[string]int, 1024)
-	isUnique := c.UseUnique
-	totalCount := 0
+	counters = make(map[string]*rankCounter)
+	maxGroups := c.MaxGroups
 	for {
 		lastLineNo++
 		line, err := r.r.Read()
@@ -192,39 +334,117 @@ func (r *RankProcessor) getRank() (RankResult, error) {
 			break
 		} else if err != nil {
 			logger.Errorf("GetRank", "r.r.Read() err:[%s]\n", err.Error())
-			return result, err
+			return counters, groups, err
 		}
 
-		text := &TextData{}
+		group := ""
+		if groupIdx >= 0 {
+			if groupIdx >= len(line) {
+				logger.Infof("GetRank", "skip the line without group column: Line:[%d]", lastLineNo)
+				continue
+			}
+			group = line[groupIdx]
+		}
+		counter, ok := counters[group]
+		if !ok && maxGroups > 0 && len(groups) >= maxGroups {
+			group = otherGroupName
+			counter, ok = counters[group]
+		}
+		if !ok {
+			counter = newRankCounter(c.UseUnique, c.Score == ScoreBM25)
+			counters[group] = counter
+			switch group {
+			case otherGroupName:
+				logger.Infof("GetRank", "group size reached the limit:[%d], the rest of groups are counted as [%s]", maxGroups, otherGroupName)
+			default:
+				groups = append(groups, group)
+			}
+		}
 
-		// tokenize text
 		lastLineText = line[idx]
-		text.raw = line[idx]
-		text.normalized = r.applyPreFilters(text.raw)
-		text.words, text.nonWords = tok.Tokenize(text.normalized)
-		if err != nil {
-			return result, err
+		counter.add(r.getWords(line))
+	}
+	if _, ok := counters[otherGroupName]; ok {
+		groups = append(groups, otherGroupName)
+	}
+	if _, ok := counters[""]; !ok && groupIdx < 0 {
+		counters[""] = newRankCounter(c.UseUnique, c.Score == ScoreBM25)
+	}
+	if c.Score == ScoreBM25 {
+		if err := r.countBM25(counters, groupIdx); err != nil {
+			return counters, groups, err
+		}
+	}
+	return counters, groups, nil
+}
+
+// countBM25 reads the input again and adds BM25 weights of the words into the counters.
+// the documents are not kept in memory, and the average length of the documents is taken from the first pass.
+func (r *RankProcessor) countBM25(counters map[string]*rankCounter, groupIdx int) (err error) {
+	c := r.Config
+	logger := c.Logger
+
+	rd, err := reader.NewFromFile(c.Input)
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+
+	lastLineNo := 1
+	lastLineText := ""
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
 		}
+		logger.Errorf("countBM25", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
+		err = fmt.Errorf("unknown error occurred on Line:[%d] err:[%v]", lastLineNo, rec)
+	}()
 
-		// create result line
-		words := text.words.GetWords()
-		wordMap := make(map[string]int, len(words))
-		for _, w := range words {
-			wordMap[w]++
+	// skip header
+	if _, err := rd.Read(); err != nil {
+		return err
+	}
+	for {
+		lastLineNo++
+		line, err := rd.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			logger.Errorf("countBM25", "rd.Read() err:[%s]\n", err.Error())
+			return err
 		}
-		for word, count := range wordMap {
-			if isUnique {
-				resultMap[word]++
-				totalCount++
+
+		group := ""
+		if groupIdx >= 0 {
+			if groupIdx >= len(line) {
 				continue
 			}
-			resultMap[word] += count
-			totalCount += count
+			group = line[groupIdx]
 		}
+		counter, ok := counters[group]
+		if !ok {
+			counter, ok = counters[otherGroupName]
+		}
+		if !ok {
+			continue
+		}
+
+		lastLineText = line[r.columnIndex]
+		counter.addBM25(r.getWords(line))
+	}
+}
+
+// getWords returns the words of the target column to count.
+func (r *RankProcessor) getWords(line []string) []string {
+	raw := line[r.columnIndex]
+	if r.Config.TermExtractor != nil {
+		return r.Config.TermExtractor(raw)
 	}
-	result.List = createWordCountList(resultMap)
-	result.TotalCount = totalCount
-	return result, nil
+
+	tok := r.tok
+	words, _ := tok.Tokenize(r.applyPreFilters(raw))
+	return tok.GetTokenKeys(words)
 }
 
 // FilterByRank filters word freqency ranking to use only top rank (or last).
@@ -256,7 +476,7 @@ func (r *RankProcessor) FilterByRank(rank RankResult, maxN int, maxP float64, ge
 	return results, nil
 }
 
-func (r *RankProcessor) output(typ string, list wordCountList) error {
+func (r *RankProcessor) output(group, typ string, list wordCountList) error {
 	c := r.Config
 	logger := c.Logger
 
@@ -264,16 +484,21 @@ func (r *RankProcessor) output(typ string, list wordCountList) error {
 		rankN := i + 1
 
 		if c.ShowResult {
-			logger.Infof("output", "[%s] #%d %s:%d (%.05f)", typ, rankN, v.word, v.count, v.percent)
+			logger.Infof("output", "%s[%s] #%d %s:%d (%.05f)%s", groupLabel(group), typ, rankN, v.word, v.count, v.percent, v.getScoreLog(c.Score))
 		}
 
-		results := []string{
+		var results []string
+		if r.groupIndex >= 0 {
+			results = append(results, group)
+		}
+		results = append(results,
 			typ,
 			strconv.Itoa(rankN),
 			v.word,
 			strconv.Itoa(v.count),
 			strconv.FormatFloat(v.percent, 'f', 5, 64),
-		}
+		)
+		results = append(results, v.getScoreValues(c.Score)...)
 		err := r.w.Write(results)
 		if err != nil {
 			logger.Errorf("output", "r.w.Write() err:[%s]\n", err.Error())
@@ -283,9 +508,17 @@ func (r *RankProcessor) output(typ string, list wordCountList) error {
 	return nil
 }
 
+func groupLabel(group string) string {
+	if group == "" {
+		return ""
+	}
+	return "[" + group + "] "
+}
+
 // RankResult has a word frequency ranking result.
 type RankResult struct {
 	TotalCount int
+	DocCount   int
 	List       wordCountList
 	TopList    wordCountList
 	LastList   wordCountList
@@ -314,11 +547,118 @@ func (r RankResult) GetLastWords() []string {
 	return list
 }
 
+// GetIDF returns idf of each word.
+func (r RankResult) GetIDF() map[string]float64 {
+	idf := make(map[string]float64, len(r.List))
+	for _, v := range r.List {
+		idf[v.word] = v.idf
+	}
+	return idf
+}
+
+// setScores calculates document frequency, idf and tf-idf of each word.
+func (r *RankResult) setScores(dfMap map[string]int) {
+	n := float64(r.DocCount)
+	for i, v := range r.List {
+		df := dfMap[v.word]
+		// smoothed idf
+		idf := math.Log((n+1)/(float64(df)+1)) + 1
+		v.df = df
+		v.idf = idf
+		v.tfidf = float64(v.count) * idf
+		r.List[i] = v
+	}
+}
+
+// setBM25 calculates BM25 of each word from the sum of the term weights over the documents.
+func (r *RankResult) setBM25(sumMap map[string]float64) {
+	n := float64(r.DocCount)
+	for i, v := range r.List {
+		df := float64(v.df)
+		idf := math.Log((n-df+0.5)/(df+0.5) + 1)
+		v.bm25 = sumMap[v.word] * idf
+		r.List[i] = v
+	}
+}
+
+// SortBy sorts the ranking list by the score type.
+func (r *RankResult) SortBy(score string) {
+	for i := range r.List {
+		r.List[i].score = r.List[i].getScore(score)
+	}
+	sort.Stable(sort.Reverse(r.List))
+}
+
 // for sorting word rank
 type wordCount struct {
 	word    string
 	count   int
 	percent float64
+
+	df    int
+	idf   float64
+	tfidf float64
+	bm25  float64
+	score float64
+}
+
+func (w wordCount) getScore(score string) float64 {
+	switch score {
+	case ScoreDF:
+		return float64(w.df)
+	case ScoreTFIDF:
+		return w.tfidf
+	case ScoreBM25:
+		return w.bm25
+	default:
+		return float64(w.count)
+	}
+}
+
+// getScoreValues returns the values of the score columns.
+func (w wordCount) getScoreValues(score string) []string {
+	switch score {
+	case ScoreDF:
+		return []string{strconv.Itoa(w.df)}
+	case ScoreTFIDF:
+		return []string{
+			strconv.Itoa(w.df),
+			strconv.FormatFloat(w.idf, 'f', 5, 64),
+			strconv.FormatFloat(w.tfidf, 'f', 5, 64),
+		}
+	case ScoreBM25:
+		return []string{
+			strconv.Itoa(w.df),
+			strconv.FormatFloat(w.bm25, 'f', 5, 64),
+		}
+	default:
+		return nil
+	}
+}
+
+// getScoreLog returns the score columns and the values for the log.
+func (w wordCount) getScoreLog(score string) string {
+	values := w.getScoreValues(score)
+	var b strings.Builder
+	for i, col := range scoreColumns(score) {
+		b.WriteString(" " + col + ":" + values[i])
+	}
+	return b.String()
+}
+
+// scoreColumns returns the column names of the score type.
+// 'count' adds no column to keep the same output as before.
+func scoreColumns(score string) []string {
+	switch score {
+	case ScoreDF:
+		return []string{"df"}
+	case ScoreTFIDF:
+		return []string{"df", "idf", "tfidf"}
+	case ScoreBM25:
+		return []string{"df", "bm25"}
+	default:
+		return nil
+	}
 }
 
 type wordCountList []wordCount
@@ -330,13 +670,112 @@ func createWordCountList(data map[string]int) wordCountList {
 		list[i] = wordCount{
 			word:  k,
 			count: v,
+			score: float64(v),
 		}
 		i++
 	}
-	sort.Sort(sort.Reverse(list))
+	// sort by the word for the same count, to get the same order on every run
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].score != list[j].score {
+			return list[i].score > list[j].score
+		}
+		return list[i].word < list[j].word
+	})
 	return list
 }
 
 func (l wordCountList) Len() int           { return len(l) }
-func (l wordCountList) Less(i, j int) bool { return l[i].count < l[j].count }
+func (l wordCountList) Less(i, j int) bool { return l[i].score < l[j].score }
 func (l wordCountList) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+
+// rankCounter counts word frequency of lines.
+type rankCounter struct {
+	isUnique bool
+	useBM25  bool
+
+	resultMap   map[string]int
+	dfMap       map[string]int
+	bm25Map     map[string]float64
+	totalCount  int
+	totalLength int
+	docCount    int
+}
+
+func newRankCounter(isUnique, useBM25 bool) *rankCounter {
+	return &rankCounter{
+		isUnique:  isUnique,
+		useBM25:   useBM25,
+		resultMap: make(map[string]int, 1024),
+		dfMap:     make(map[string]int, 1024),
+		bm25Map:   make(map[string]float64),
+	}
+}
+
+// add counts words of a line.
+func (c *rankCounter) add(words []string) {
+	wordMap := make(map[string]int, len(words))
+	for _, w := range words {
+		wordMap[w]++
+	}
+	c.docCount++
+	c.totalLength += len(words)
+	for word, count := range wordMap {
+		c.dfMap[word]++
+		if c.isUnique {
+			c.resultMap[word]++
+			c.totalCount++
+			continue
+		}
+		c.resultMap[word] += count
+		c.totalCount += count
+	}
+}
+
+// addBM25 adds BM25 term weights of the words of a line.
+// it must be called after all of the lines are counted by add, to use the average length of the lines.
+func (c *rankCounter) addBM25(words []string) {
+	if c.totalLength == 0 {
+		return
+	}
+	avgLength := float64(c.totalLength) / float64(c.docCount)
+	norm := bm25K1 * (1 - bm25B + bm25B*float64(len(words))/avgLength)
+
+	wordMap := make(map[string]int, len(words))
+	for _, w := range words {
+		wordMap[w]++
+	}
+	for word, count := range wordMap {
+		tf := float64(count)
+		c.bm25Map[word] += tf * (bm25K1 + 1) / (tf + norm)
+	}
+}
+
+// merge adds the counts of other counter.
+func (c *rankCounter) merge(other *rankCounter) {
+	for word, count := range other.resultMap {
+		c.resultMap[word] += count
+	}
+	for word, count := range other.dfMap {
+		c.dfMap[word] += count
+	}
+	for word, weight := range other.bm25Map {
+		c.bm25Map[word] += weight
+	}
+	c.totalCount += other.totalCount
+	c.totalLength += other.totalLength
+	c.docCount += other.docCount
+}
+
+// result returns the ranking sorted by the score type.
+func (c *rankCounter) result(score string) RankResult {
+	result := RankResult{}
+	result.List = createWordCountList(c.resultMap)
+	result.TotalCount = c.totalCount
+	result.DocCount = c.docCount
+	result.setScores(c.dfMap)
+	if c.useBM25 {
+		result.setBM25(c.bm25Map)
+	}
+	result.SortBy(score)
+	return result
+}
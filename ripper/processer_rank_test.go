@@ -0,0 +1,182 @@
+package ripper
+
+import (
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/evalphobia/go-jp-text-ripper/log"
+)
+
+func TestRankCounterResult(t *testing.T) {
+	docs := [][]string{
+		{"apple", "banana", "apple"},
+		{"banana", "cherry"},
+		{"cherry", "durian", "apple", "banana"},
+	}
+	c := newRankCounter(false, true)
+	for _, d := range docs {
+		c.add(d)
+	}
+	for _, d := range docs {
+		c.addBM25(d)
+	}
+
+	// avg length = 3
+	idf := func(df float64) float64 { return math.Log(4/(df+1)) + 1 }
+	bm25IDF := func(df float64) float64 { return math.Log((3-df+0.5)/(df+0.5) + 1) }
+	bm25TF := func(tf, length float64) float64 {
+		return tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/3))
+	}
+
+	tests := []struct {
+		word  string
+		count int
+		df    int
+		tfidf float64
+		bm25  float64
+	}{
+		{"apple", 3, 2, 3 * idf(2), (bm25TF(2, 3) + bm25TF(1, 4)) * bm25IDF(2)},
+		{"banana", 3, 3, 3 * idf(3), (bm25TF(1, 3) + bm25TF(1, 2) + bm25TF(1, 4)) * bm25IDF(3)},
+		{"cherry", 2, 2, 2 * idf(2), (bm25TF(1, 2) + bm25TF(1, 4)) * bm25IDF(2)},
+		{"durian", 1, 1, 1 * idf(1), bm25TF(1, 4) * bm25IDF(1)},
+	}
+
+	result := c.result(ScoreCount)
+	if result.TotalCount != 9 || result.DocCount != 3 {
+		t.Errorf("result() TotalCount = %d DocCount = %d, want 9 and 3", result.TotalCount, result.DocCount)
+	}
+	if len(result.List) != len(tests) {
+		t.Fatalf("result() size = %d, want %d", len(result.List), len(tests))
+	}
+	for i, tt := range tests {
+		v := result.List[i]
+		if v.word != tt.word || v.count != tt.count || v.df != tt.df {
+			t.Errorf("result().List[%d] = %s:%d df:%d, want %s:%d df:%d", i, v.word, v.count, v.df, tt.word, tt.count, tt.df)
+		}
+		if !almostEqual(v.tfidf, tt.tfidf) {
+			t.Errorf("result().List[%d] tfidf = %f, want %f", i, v.tfidf, tt.tfidf)
+		}
+		if !almostEqual(v.bm25, tt.bm25) {
+			t.Errorf("result().List[%d] bm25 = %f, want %f", i, v.bm25, tt.bm25)
+		}
+	}
+}
+
+func TestRankCounterResultOrder(t *testing.T) {
+	tests := []struct {
+		score    string
+		isUnique bool
+		expected []string
+	}{
+		// the same count is sorted by the word
+		{ScoreCount, false, []string{"a", "d", "b", "c"}},
+		{ScoreCount, true, []string{"b", "c", "d", "a"}},
+		// the same score is sorted by the count and the word
+		{ScoreDF, false, []string{"d", "b", "c", "a"}},
+		{ScoreTFIDF, false, []string{"a", "d", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		c := newRankCounter(tt.isUnique, false)
+		c.add([]string{"a", "a", "a", "b", "c"})
+		c.add([]string{"c", "b", "d"})
+		c.add([]string{"d", "d"})
+
+		result := c.result(tt.score)
+		var got []string
+		for _, v := range result.List {
+			got = append(got, v.word)
+		}
+		if !equalStrings(got, tt.expected) {
+			t.Errorf("result(%q) unique:%v = %v, want %v", tt.score, tt.isUnique, got, tt.expected)
+		}
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRankProcessorGetGroupRank(t *testing.T) {
+	path := writeTestFile(t, "input.csv", "id,group,text\n1,a,りんご\n2,b,みかん\n3,a,りんご\n4\n5,c,ぶどう\n")
+
+	tests := []struct {
+		maxGroups int
+		expected  map[string][]string
+	}{
+		{1000, map[string][]string{"a": {"りんご"}, "b": {"みかん"}, "c": {"ぶどう"}}},
+		{1, map[string][]string{"a": {"りんご"}, otherGroupName: {"ぶどう", "みかん"}}},
+	}
+
+	for _, tt := range tests {
+		r, err := NewRankProcessor(RankConfig{
+			CommonConfig: CommonConfig{Input: path, Column: "text", Logger: log.DefaultLogger},
+			GroupBy:      "group",
+			MaxGroups:    tt.maxGroups,
+			TopNumber:    10,
+		})
+		if err != nil {
+			t.Fatalf("NewRankProcessor() err = %v", err)
+		}
+		if err := r.ReadHeader(); err != nil {
+			t.Fatalf("ReadHeader() err = %v", err)
+		}
+
+		// the line without the group column is skipped
+		ranks, groups, err := r.GetGroupRank()
+		if err != nil {
+			t.Fatalf("GetGroupRank() err = %v", err)
+		}
+		if len(groups) != len(tt.expected) {
+			t.Errorf("GetGroupRank() maxGroups:%d groups = %v, want %d groups", tt.maxGroups, groups, len(tt.expected))
+		}
+		for g, words := range tt.expected {
+			if got := ranks[g].GetTopWords(); !equalStrings(got, words) {
+				t.Errorf("GetGroupRank() maxGroups:%d [%s] = %v, want %v", tt.maxGroups, g, got, words)
+			}
+		}
+		r.Close()
+	}
+}
+
+func TestRankProcessorGetRankError(t *testing.T) {
+	// the line without the target column
+	path := writeTestFile(t, "input.csv", "id,text\n1,りんご\n2\n")
+
+	r, err := NewRankProcessor(RankConfig{
+		CommonConfig: CommonConfig{Input: path, Column: "text", Logger: log.DefaultLogger},
+	})
+	if err != nil {
+		t.Fatalf("NewRankProcessor() err = %v", err)
+	}
+	defer r.Close()
+	if err := r.ReadHeader(); err != nil {
+		t.Fatalf("ReadHeader() err = %v", err)
+	}
+	if _, err := r.GetRank(); err == nil {
+		t.Errorf("GetRank() err = nil, want error")
+	}
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("ioutil.WriteFile() err = %v", err)
+	}
+	return path
+}
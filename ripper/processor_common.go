@@ -1,6 +1,7 @@
 package ripper
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -34,6 +35,9 @@ func NewCommonProcessor(c CommonConfig) (*CommonProcessor, error) {
 			StopWordList:    c.StopWords,
 			MinLetterSize:   c.MinLetterSize,
 			UseOriginalForm: c.UseOriginalForm,
+			KeyFunc:         c.GetKeyFunc(),
+			UseReadingKey:   c.UseReadingKey,
+			NumberMask:      c.NumberMask,
 		}),
 	}
 
@@ -141,6 +145,51 @@ func (r *CommonProcessor) ReadHeaderWithIndex(idx int) error {
 	return nil
 }
 
+// ReadHeaderWithName reads header columns and sets target column by name.
+func (r *CommonProcessor) ReadHeaderWithName(col string) error {
+	err := r.ReadHeader()
+	if err != nil {
+		return err
+	}
+
+	idx := r.GetColumnIndex(col)
+	if idx < 0 {
+		return fmt.Errorf("cannnot find column name in header: col:[%s] headers:[%+v]", col, r.inputHeader)
+	}
+	r.SetColumnIndex(idx)
+	return nil
+}
+
+// ReadTargetHeader reads header columns and sets target column from the config.
+func (r *CommonProcessor) ReadTargetHeader() error {
+	c := r.Config
+	switch {
+	case c.ColumnNumber > 0:
+		return r.ReadHeaderWithIndex(c.ColumnNumber - 1)
+	default:
+		return r.ReadHeaderWithName(c.Column)
+	}
+}
+
+// GetColumnIndex returns index of the column name in the input header (not found=-1).
+func (r *CommonProcessor) GetColumnIndex(col string) int {
+	for idx, val := range r.inputHeader {
+		if val == col {
+			return idx
+		}
+	}
+	return -1
+}
+
+// tokenizeLine reads the target column of the line and tokenizes it.
+func (r *CommonProcessor) tokenizeLine(line []string) *TextData {
+	text := &TextData{tok: r.tok}
+	text.raw = line[r.columnIndex]
+	text.normalized = r.applyPreFilters(text.raw)
+	text.words, text.nonWords = r.tok.Tokenize(text.normalized)
+	return text
+}
+
 // applyPreFilters runs prefilters function and return normalized text
 func (r *CommonProcessor) applyPreFilters(text string) string {
 	for _, p := range r.preFilters {
@@ -0,0 +1,346 @@
+package ripper
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/evalphobia/go-jp-text-ripper/writer"
+)
+
+// keyness types.
+const (
+	keynessPositive = "positive"
+	keynessNegative = "negative"
+)
+
+// DoCompare creates *CompareProcessor from config and run it.
+func DoCompare(conf CompareConfig) error {
+	if err := conf.Init(); err != nil {
+		return err
+	}
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	conf.Logger.Infof("DoCompare", "version:[%s] rev:[%s]", conf.Version, conf.Revision)
+	r, err := NewCompareProcessor(conf)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := r.WriteHeader(); err != nil {
+		return err
+	}
+
+	return r.DoWithProgress()
+}
+
+// CompareProcessor is struct for keyness comparison between two corpora.
+type CompareProcessor struct {
+	Config CompareConfig
+
+	target    *RankProcessor
+	reference *RankProcessor
+	w         *writer.Writer
+}
+
+// NewCompareProcessor returns initialized CompareProcessor.
+func NewCompareProcessor(c CompareConfig) (*CompareProcessor, error) {
+	r := &CompareProcessor{
+		Config: c,
+	}
+
+	var err error
+	r.target, err = NewRankProcessor(c.getRankConfig(c.Input))
+	if err != nil {
+		return nil, err
+	}
+	if !c.UseLabel() {
+		r.reference, err = NewRankProcessor(c.getRankConfig(c.Reference))
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+	}
+
+	switch {
+	case c.Output == "":
+		r.w = writer.NewDummy()
+	default:
+		r.w, err = writer.NewFromFile(c.Output)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Close closes opened files
+func (r *CompareProcessor) Close() {
+	logger := r.Config.Logger
+	if r.target != nil {
+		r.target.Close()
+	}
+	if r.reference != nil {
+		r.reference.Close()
+	}
+	if r.w != nil {
+		if err := r.w.Close(); err != nil {
+			logger.Errorf("Close", "r.w.Close() err:[%s]\n", err.Error())
+		}
+	}
+}
+
+// WriteHeader writes header columns
+func (r *CompareProcessor) WriteHeader() error {
+	if err := r.target.ReadHeader(); err != nil {
+		return err
+	}
+	if r.reference != nil {
+		if err := r.reference.ReadHeader(); err != nil {
+			return err
+		}
+	}
+
+	return r.w.Write([]string{
+		"type",
+		"rank",
+		"word",
+		"count_target",
+		"count_reference",
+		"g2",
+		"chi2",
+		"log_ratio",
+	})
+}
+
+// DoWithProgress processes with showing progress.
+func (r *CompareProcessor) DoWithProgress() error {
+	r.target.ShowProgress()
+
+	conf := r.Config
+	logger := conf.Logger
+	logger.Infof("DoWithProgress", "read lines...")
+
+	err := r.Do()
+	if err != nil {
+		logger.Errorf("DoWithProgress", "error on r.Do() err:[%s]", err.Error())
+		return err
+	}
+
+	logger.Infof("DoWithProgress", "finish process")
+	return nil
+}
+
+// Do processes keyness comparison.
+func (r *CompareProcessor) Do() error {
+	defer r.Close()
+	c := r.Config
+	logger := c.Logger
+
+	result, err := r.GetKeyness()
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Do", "Total Words: target:%d reference:%d", result.TargetTotal, result.ReferenceTotal)
+
+	if err := r.output(keynessPositive, result.PositiveList); err != nil {
+		return err
+	}
+	return r.output(keynessNegative, result.NegativeList)
+}
+
+// GetKeyness gets result of keyness comparison.
+func (r *CompareProcessor) GetKeyness() (KeynessResult, error) {
+	target, reference, err := r.getCounters()
+	if err != nil {
+		return KeynessResult{}, err
+	}
+
+	c := r.Config
+	result := newKeynessResult(target, reference, c.MinCount)
+	result.PositiveList = result.PositiveList.limit(c.TopNumber)
+	result.NegativeList = result.NegativeList.limit(c.TopNumber)
+	return result, nil
+}
+
+// getCounters counts words of the target and reference corpus.
+func (r *CompareProcessor) getCounters() (target, reference *rankCounter, err error) {
+	c := r.Config
+	if !c.UseLabel() {
+		target, err = r.target.countAll()
+		if err != nil {
+			return nil, nil, err
+		}
+		reference, err = r.reference.countAll()
+		return target, reference, err
+	}
+
+	counters, groups, err := r.target.countWords(r.target.groupIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	target = newRankCounter(c.UseUnique, false)
+	reference = newRankCounter(c.UseUnique, false)
+	for _, g := range groups {
+		switch g {
+		case c.TargetLabel:
+			target.merge(counters[g])
+		default:
+			reference.merge(counters[g])
+		}
+	}
+	return target, reference, nil
+}
+
+func (r *CompareProcessor) output(typ string, list keynessList) error {
+	c := r.Config
+	logger := c.Logger
+
+	for i, v := range list {
+		rankN := i + 1
+
+		if c.ShowResult {
+			logger.Infof("output", "[%s] #%d %s:%d/%d G2:%.03f log_ratio:%.03f", typ, rankN, v.word, v.countTarget, v.countReference, v.g2, v.logRatio)
+		}
+
+		err := r.w.Write([]string{
+			typ,
+			strconv.Itoa(rankN),
+			v.word,
+			strconv.Itoa(v.countTarget),
+			strconv.Itoa(v.countReference),
+			strconv.FormatFloat(v.g2, 'f', 5, 64),
+			strconv.FormatFloat(v.chi2, 'f', 5, 64),
+			strconv.FormatFloat(v.logRatio, 'f', 5, 64),
+		})
+		if err != nil {
+			logger.Errorf("output", "r.w.Write() err:[%s]\n", err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// KeynessResult has a keyness comparison result.
+type KeynessResult struct {
+	TargetTotal    int
+	ReferenceTotal int
+	// words overused in the target corpus
+	PositiveList keynessList
+	// words underused in the target corpus
+	NegativeList keynessList
+}
+
+func newKeynessResult(target, reference *rankCounter, minCount int) KeynessResult {
+	result := KeynessResult{
+		TargetTotal:    target.totalCount,
+		ReferenceTotal: reference.totalCount,
+	}
+
+	words := make(map[string]struct{}, len(target.resultMap)+len(reference.resultMap))
+	for w := range target.resultMap {
+		words[w] = struct{}{}
+	}
+	for w := range reference.resultMap {
+		words[w] = struct{}{}
+	}
+
+	for w := range words {
+		a := target.resultMap[w]
+		b := reference.resultMap[w]
+		if a+b < minCount {
+			continue
+		}
+
+		v := newKeyness(w, a, b, result.TargetTotal, result.ReferenceTotal)
+		switch {
+		case v.logRatio >= 0:
+			result.PositiveList = append(result.PositiveList, v)
+		default:
+			result.NegativeList = append(result.NegativeList, v)
+		}
+	}
+	sort.Sort(sort.Reverse(result.PositiveList))
+	sort.Sort(sort.Reverse(result.NegativeList))
+	return result
+}
+
+// keyness is statistics of the word between two corpora.
+type keyness struct {
+	word           string
+	countTarget    int
+	countReference int
+
+	g2       float64
+	chi2     float64
+	logRatio float64
+}
+
+// newKeyness calculates log-likelihood(G2), chi-square and log ratio.
+func newKeyness(word string, a, b, c, d int) keyness {
+	fa, fb, fc, fd := float64(a), float64(b), float64(c), float64(d)
+	n := fc + fd
+
+	k := keyness{
+		word:           word,
+		countTarget:    a,
+		countReference: b,
+	}
+	if fc == 0 || fd == 0 {
+		return k
+	}
+
+	e1 := fc * (fa + fb) / n
+	e2 := fd * (fa + fb) / n
+	k.g2 = 2 * (xLogXY(fa, e1) + xLogXY(fb, e2))
+
+	denom := (fa + fb) * (n - fa - fb) * fc * fd
+	if denom > 0 {
+		diff := fa*(fd-fb) - fb*(fc-fa)
+		k.chi2 = n * diff * diff / denom
+	}
+
+	// use 0.5 for zero frequency
+	if fa == 0 {
+		fa = 0.5
+	}
+	if fb == 0 {
+		fb = 0.5
+	}
+	k.logRatio = math.Log2((fa / fc) / (fb / fd))
+	return k
+}
+
+// xLogXY returns x*log(x/y), and 0 when x is 0.
+func xLogXY(x, y float64) float64 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return x * math.Log(x/y)
+}
+
+type keynessList []keyness
+
+func (l keynessList) limit(n int) keynessList {
+	if len(l) > n {
+		return l[:n]
+	}
+	return l
+}
+
+func (l keynessList) Len() int { return len(l) }
+
+// Less sorts by G2, and by the word in reverse order for the same G2 to get the same order on every run.
+func (l keynessList) Less(i, j int) bool {
+	if l[i].g2 != l[j].g2 {
+		return l[i].g2 < l[j].g2
+	}
+	return l[i].word > l[j].word
+}
+
+func (l keynessList) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
@@ -0,0 +1,63 @@
+package ripper
+
+import "testing"
+
+func TestNewKeyness(t *testing.T) {
+	tests := []struct {
+		a, b, c, d int
+		g2         float64
+		chi2       float64
+		logRatio   float64
+	}{
+		{10, 5, 100, 200, 6.931471805599453, 7.894736842105263, 2},
+		// the same ratio
+		{5, 10, 100, 200, 0, 0, 0},
+		// 0.5 is used for zero frequency
+		{0, 10, 100, 200, 8.109302162163289, 5.172413793103448, -3.321928094887362},
+		// empty corpus
+		{1, 0, 1, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		k := newKeyness("w", tt.a, tt.b, tt.c, tt.d)
+		if !almostEqual(k.g2, tt.g2) || !almostEqual(k.chi2, tt.chi2) || !almostEqual(k.logRatio, tt.logRatio) {
+			t.Errorf("newKeyness(%d, %d, %d, %d) = g2:%v chi2:%v logRatio:%v, want g2:%v chi2:%v logRatio:%v",
+				tt.a, tt.b, tt.c, tt.d, k.g2, k.chi2, k.logRatio, tt.g2, tt.chi2, tt.logRatio)
+		}
+	}
+}
+
+func TestNewKeynessResult(t *testing.T) {
+	target := newRankCounter(false, false)
+	target.add([]string{"a", "a", "a", "b", "c", "x", "z"})
+	reference := newRankCounter(false, false)
+	reference.add([]string{"a", "b", "b", "b", "c", "y"})
+
+	tests := []struct {
+		minCount int
+		positive []string
+		negative []string
+	}{
+		// the same G2 is sorted by the word
+		{1, []string{"x", "z", "a"}, []string{"y", "b", "c"}},
+		{2, []string{"a"}, []string{"b", "c"}},
+	}
+
+	for _, tt := range tests {
+		result := newKeynessResult(target, reference, tt.minCount)
+		if got := keynessWords(result.PositiveList); !equalStrings(got, tt.positive) {
+			t.Errorf("newKeynessResult(%d).PositiveList = %v, want %v", tt.minCount, got, tt.positive)
+		}
+		if got := keynessWords(result.NegativeList); !equalStrings(got, tt.negative) {
+			t.Errorf("newKeynessResult(%d).NegativeList = %v, want %v", tt.minCount, got, tt.negative)
+		}
+	}
+}
+
+func keynessWords(list keynessList) []string {
+	words := make([]string, len(list))
+	for i, v := range list {
+		words[i] = v.word
+	}
+	return words
+}
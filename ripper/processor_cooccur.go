@@ -0,0 +1,419 @@
+package ripper
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/evalphobia/go-jp-text-ripper/writer"
+)
+
+// DoCooccur creates *CooccurProcessor from config and run it.
+func DoCooccur(conf CooccurConfig) error {
+	if err := conf.Init(); err != nil {
+		return err
+	}
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	conf.Logger.Infof("DoCooccur", "version:[%s] rev:[%s]", conf.Version, conf.Revision)
+	r, err := NewCooccurProcessor(conf)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := r.WriteHeader(); err != nil {
+		return err
+	}
+
+	return r.DoWithProgress()
+}
+
+// CooccurProcessor is struct for word co-occurrence.
+type CooccurProcessor struct {
+	*CommonProcessor
+	Config CooccurConfig
+}
+
+// NewCooccurProcessor returns initialized CooccurProcessor.
+func NewCooccurProcessor(c CooccurConfig) (*CooccurProcessor, error) {
+	common, err := NewCommonProcessor(c.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &CooccurProcessor{
+		CommonProcessor: common,
+		Config:          c,
+	}
+	return r, nil
+}
+
+// WriteHeader writes header columns
+func (r *CooccurProcessor) WriteHeader() error {
+	// read header if not read yet
+	if len(r.inputHeader) == 0 {
+		err := r.ReadTargetHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	r.outputHeader = []string{
+		"rank",
+		"word1",
+		"word2",
+		"count",
+		"count1",
+		"count2",
+		"pmi",
+		"npmi",
+		"dice",
+		"tscore",
+	}
+
+	// write to file
+	return r.w.Write(r.outputHeader)
+}
+
+// DoWithProgress processes with showing progress.
+func (r *CooccurProcessor) DoWithProgress() error {
+	r.ShowProgress()
+
+	conf := r.Config
+	logger := conf.Logger
+	logger.Infof("DoWithProgress", "read lines...")
+
+	err := r.Do()
+	if err != nil {
+		logger.Errorf("DoWithProgress", "error on r.Do() err:[%s]", err.Error())
+		return err
+	}
+
+	logger.Infof("DoWithProgress", "finish process")
+	return nil
+}
+
+// Do processes word co-occurrence.
+func (r *CooccurProcessor) Do() error {
+	defer r.Close()
+	c := r.Config
+	logger := c.Logger
+
+	result, err := r.GetCooccurrence()
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Do", "Total Pairs:%d", result.TotalPairSize)
+	if err := r.output(result.List); err != nil {
+		return err
+	}
+
+	if c.GraphOutput == "" {
+		return nil
+	}
+	return writer.WriteGraphToFile(c.GraphOutput, result.List.toGraph(result.wordCount))
+}
+
+// GetCooccurrence gets result of word co-occurrence.
+func (r *CooccurProcessor) GetCooccurrence() (CooccurResult, error) {
+	c := r.Config
+
+	counter, err := r.count()
+	if err != nil {
+		return CooccurResult{}, err
+	}
+
+	result := counter.result(c.MinCount, c.MinWordCount, c.Score)
+	if len(result.List) > c.TopNumber {
+		result.List = result.List[:c.TopNumber]
+	}
+	return result, nil
+}
+
+func (r *CooccurProcessor) count() (counter *cooccurCounter, err error) {
+	defer r.r.Close()
+	c := r.Config
+	logger := c.Logger
+
+	lastLineNo := 1
+	lastLineText := ""
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		logger.Errorf("count", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
+		counter = nil
+		err = fmt.Errorf("unknown error occurred on Line:[%d] err:[%v]", lastLineNo, rec)
+	}()
+
+	counter = newCooccurCounter(c.WindowSize)
+	for {
+		lastLineNo++
+		line, err := r.r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			logger.Errorf("count", "r.r.Read() err:[%s]\n", err.Error())
+			return nil, err
+		}
+
+		lastLineText = line[r.columnIndex]
+		text := r.tokenizeLine(line)
+		counter.add(r.tok.GetTokenKeys(text.words))
+	}
+	return counter, nil
+}
+
+func (r *CooccurProcessor) output(list cooccurList) error {
+	c := r.Config
+	logger := c.Logger
+
+	for i, v := range list {
+		rankN := i + 1
+
+		if c.ShowResult {
+			logger.Infof("output", "#%d %s - %s:%d pmi:%.03f dice:%.03f", rankN, v.word1, v.word2, v.count, v.pmi, v.dice)
+		}
+
+		err := r.w.Write([]string{
+			strconv.Itoa(rankN),
+			v.word1,
+			v.word2,
+			strconv.Itoa(v.count),
+			strconv.Itoa(v.count1),
+			strconv.Itoa(v.count2),
+			strconv.FormatFloat(v.pmi, 'f', 5, 64),
+			strconv.FormatFloat(v.npmi, 'f', 5, 64),
+			strconv.FormatFloat(v.dice, 'f', 5, 64),
+			strconv.FormatFloat(v.tscore, 'f', 5, 64),
+		})
+		if err != nil {
+			logger.Errorf("output", "r.w.Write() err:[%s]\n", err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// CooccurResult has a word co-occurrence result.
+type CooccurResult struct {
+	TotalPairSize int
+	List          cooccurList
+
+	wordCount map[string]int
+}
+
+// wordPair is a key of co-occurrence, word1 < word2.
+type wordPair struct {
+	word1 string
+	word2 string
+}
+
+func newWordPair(a, b string) wordPair {
+	if a > b {
+		a, b = b, a
+	}
+	return wordPair{
+		word1: a,
+		word2: b,
+	}
+}
+
+// cooccurCounter counts word pairs.
+//
+// when window size is 0, the words and pairs are counted per line (document frequency),
+// otherwise they are counted per sliding window of (window size + 1) tokens,
+// so the count of a pair never exceeds the count of each word.
+type cooccurCounter struct {
+	windowSize int
+
+	wordCount map[string]int
+	pairCount map[wordPair]int
+	total     int
+}
+
+func newCooccurCounter(windowSize int) *cooccurCounter {
+	return &cooccurCounter{
+		windowSize: windowSize,
+		wordCount:  make(map[string]int, 1024),
+		pairCount:  make(map[wordPair]int, 1024),
+	}
+}
+
+// add counts words and pairs of a line.
+func (c *cooccurCounter) add(words []string) {
+	if c.windowSize > 0 {
+		c.addWindow(words)
+		return
+	}
+	c.addUnit(words)
+}
+
+// addWindow counts words and pairs of each sliding window in a line.
+func (c *cooccurCounter) addWindow(words []string) {
+	size := c.windowSize + 1
+	if len(words) <= size {
+		c.addUnit(words)
+		return
+	}
+	for i := 0; i+size <= len(words); i++ {
+		c.addUnit(words[i : i+size])
+	}
+}
+
+// addUnit counts distinct words and pairs of a unit (line or window).
+func (c *cooccurCounter) addUnit(words []string) {
+	uniq := make([]string, 0, len(words))
+	seen := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		if _, ok := seen[w]; ok {
+			continue
+		}
+		seen[w] = struct{}{}
+		uniq = append(uniq, w)
+		c.wordCount[w]++
+	}
+	c.total++
+
+	for i, a := range uniq {
+		for _, b := range uniq[i+1:] {
+			c.pairCount[newWordPair(a, b)]++
+		}
+	}
+}
+
+// result returns the pairs sorted by the score type.
+func (c *cooccurCounter) result(minCount, minWordCount int, score string) CooccurResult {
+	n := float64(c.total)
+	list := make(cooccurList, 0, len(c.pairCount))
+	for p, count := range c.pairCount {
+		if count < minCount {
+			continue
+		}
+		c1 := c.wordCount[p.word1]
+		c2 := c.wordCount[p.word2]
+		if c1 < minWordCount || c2 < minWordCount {
+			continue
+		}
+
+		v := cooccur{
+			word1:  p.word1,
+			word2:  p.word2,
+			count:  count,
+			count1: c1,
+			count2: c2,
+		}
+		v.setScores(n)
+		v.score = v.getScore(score)
+		list = append(list, v)
+	}
+	sort.Sort(sort.Reverse(list))
+
+	return CooccurResult{
+		TotalPairSize: len(c.pairCount),
+		List:          list,
+		wordCount:     c.wordCount,
+	}
+}
+
+// cooccur is statistics of the word pair.
+type cooccur struct {
+	word1  string
+	word2  string
+	count  int
+	count1 int
+	count2 int
+
+	pmi    float64
+	npmi   float64
+	dice   float64
+	tscore float64
+	score  float64
+}
+
+// setScores calculates PMI, NPMI, Dice coefficient and t-score.
+func (v *cooccur) setScores(n float64) {
+	fxy := float64(v.count)
+	fx := float64(v.count1)
+	fy := float64(v.count2)
+	if n == 0 || fx == 0 || fy == 0 {
+		return
+	}
+
+	v.pmi = math.Log2(fxy * n / (fx * fy))
+	if pxy := fxy / n; pxy < 1 {
+		v.npmi = v.pmi / -math.Log2(pxy)
+	} else {
+		v.npmi = 1
+	}
+	v.dice = 2 * fxy / (fx + fy)
+	v.tscore = (fxy - fx*fy/n) / math.Sqrt(fxy)
+}
+
+func (v cooccur) getScore(score string) float64 {
+	switch score {
+	case ScorePMI:
+		return v.pmi
+	case ScoreNPMI:
+		return v.npmi
+	case ScoreDice:
+		return v.dice
+	case ScoreTScore:
+		return v.tscore
+	default:
+		return float64(v.count)
+	}
+}
+
+type cooccurList []cooccur
+
+func (l cooccurList) toGraph(wordCount map[string]int) writer.Graph {
+	g := writer.Graph{}
+	nodes := make(map[string]struct{})
+	addNode := func(w string) {
+		if _, ok := nodes[w]; ok {
+			return
+		}
+		nodes[w] = struct{}{}
+		g.Nodes = append(g.Nodes, writer.GraphNode{
+			ID:     w,
+			Label:  w,
+			Weight: wordCount[w],
+		})
+	}
+
+	for _, v := range l {
+		addNode(v.word1)
+		addNode(v.word2)
+		g.Edges = append(g.Edges, writer.GraphEdge{
+			Source: v.word1,
+			Target: v.word2,
+			Weight: v.score,
+			Count:  v.count,
+		})
+	}
+	return g
+}
+
+func (l cooccurList) Len() int { return len(l) }
+
+// Less sorts by the score, and the same score is sorted by the words in reverse order,
+// so the reversed sort returns the words in alphabetical order.
+func (l cooccurList) Less(i, j int) bool {
+	switch {
+	case l[i].score != l[j].score:
+		return l[i].score < l[j].score
+	case l[i].word1 != l[j].word1:
+		return l[i].word1 > l[j].word1
+	}
+	return l[i].word2 > l[j].word2
+}
+
+func (l cooccurList) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
@@ -0,0 +1,127 @@
+package ripper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCooccurSetScores(t *testing.T) {
+	tests := []struct {
+		count, count1, count2 int
+		n                     float64
+		pmi                   float64
+		npmi                  float64
+		dice                  float64
+		tscore                float64
+	}{
+		{2, 4, 3, 10, 0.7369655941662062, 0.31739380551401475, 0.5714285714285714, 0.565685424949238},
+		// the pair occurs in every unit
+		{2, 2, 2, 2, 0, 1, 1, 0},
+		// empty counter
+		{0, 0, 0, 0, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		v := cooccur{count: tt.count, count1: tt.count1, count2: tt.count2}
+		v.setScores(tt.n)
+		if !almostEqual(v.pmi, tt.pmi) || !almostEqual(v.npmi, tt.npmi) || !almostEqual(v.dice, tt.dice) || !almostEqual(v.tscore, tt.tscore) {
+			t.Errorf("setScores(%d, %d, %d, %v) = pmi:%v npmi:%v dice:%v tscore:%v, want pmi:%v npmi:%v dice:%v tscore:%v",
+				tt.count, tt.count1, tt.count2, tt.n, v.pmi, v.npmi, v.dice, v.tscore, tt.pmi, tt.npmi, tt.dice, tt.tscore)
+		}
+	}
+}
+
+func TestCooccurCounterAdd(t *testing.T) {
+	tests := []struct {
+		windowSize int
+		lines      [][]string
+		total      int
+		wordCount  map[string]int
+		pairCount  map[wordPair]int
+	}{
+		// counted per line, duplicated words are counted once
+		{
+			windowSize: 0,
+			lines:      [][]string{{"a", "b", "a", "c"}, {"b", "a"}},
+			total:      2,
+			wordCount:  map[string]int{"a": 2, "b": 2, "c": 1},
+			pairCount: map[wordPair]int{
+				{"a", "b"}: 2,
+				{"a", "c"}: 1,
+				{"b", "c"}: 1,
+			},
+		},
+		// counted per sliding window
+		{
+			windowSize: 1,
+			lines:      [][]string{{"a", "b", "c", "d"}},
+			total:      3,
+			wordCount:  map[string]int{"a": 1, "b": 2, "c": 2, "d": 1},
+			pairCount: map[wordPair]int{
+				{"a", "b"}: 1,
+				{"b", "c"}: 1,
+				{"c", "d"}: 1,
+			},
+		},
+		// a line shorter than the window is counted as one unit
+		{
+			windowSize: 2,
+			lines:      [][]string{{"a", "b"}},
+			total:      1,
+			wordCount:  map[string]int{"a": 1, "b": 1},
+			pairCount:  map[wordPair]int{{"a", "b"}: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		c := newCooccurCounter(tt.windowSize)
+		for _, words := range tt.lines {
+			c.add(words)
+		}
+		if c.total != tt.total {
+			t.Errorf("windowSize:%d total = %d, want %d", tt.windowSize, c.total, tt.total)
+		}
+		if !reflect.DeepEqual(c.wordCount, tt.wordCount) {
+			t.Errorf("windowSize:%d wordCount = %v, want %v", tt.windowSize, c.wordCount, tt.wordCount)
+		}
+		if !reflect.DeepEqual(c.pairCount, tt.pairCount) {
+			t.Errorf("windowSize:%d pairCount = %v, want %v", tt.windowSize, c.pairCount, tt.pairCount)
+		}
+	}
+}
+
+func TestCooccurCounterResult(t *testing.T) {
+	c := newCooccurCounter(0)
+	c.add([]string{"a", "b", "c"})
+	c.add([]string{"a", "b"})
+	c.add([]string{"d", "e"})
+
+	tests := []struct {
+		minCount     int
+		minWordCount int
+		score        string
+		want         []wordPair
+	}{
+		// the same count is sorted by the words
+		{1, 1, ScoreCount, []wordPair{{"a", "b"}, {"a", "c"}, {"b", "c"}, {"d", "e"}}},
+		{2, 1, ScoreCount, []wordPair{{"a", "b"}}},
+		{1, 2, ScoreCount, []wordPair{{"a", "b"}}},
+		// pmi of an exclusive pair is higher
+		{1, 1, ScorePMI, []wordPair{{"d", "e"}, {"a", "b"}, {"a", "c"}, {"b", "c"}}},
+	}
+
+	for _, tt := range tests {
+		result := c.result(tt.minCount, tt.minWordCount, tt.score)
+		if result.TotalPairSize != 4 {
+			t.Errorf("result(%d, %d, %q).TotalPairSize = %d, want 4", tt.minCount, tt.minWordCount, tt.score, result.TotalPairSize)
+		}
+
+		got := make([]wordPair, len(result.List))
+		for i, v := range result.List {
+			got[i] = wordPair{word1: v.word1, word2: v.word2}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("result(%d, %d, %q) = %v, want %v", tt.minCount, tt.minWordCount, tt.score, got, tt.want)
+		}
+	}
+}
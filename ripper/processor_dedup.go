@@ -0,0 +1,248 @@
+package ripper
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// DoDedup creates *DedupProcessor from config and run it.
+func DoDedup(conf DedupConfig) error {
+	if err := conf.Init(); err != nil {
+		return err
+	}
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	conf.Logger.Infof("DoDedup", "version:[%s] rev:[%s]", conf.Version, conf.Revision)
+	r, err := NewDedupProcessor(conf)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := r.WriteHeader(); err != nil {
+		return err
+	}
+
+	return r.DoWithProgress()
+}
+
+// DedupProcessor is struct for near-duplicate detection.
+type DedupProcessor struct {
+	*CommonProcessor
+	Config DedupConfig
+}
+
+// NewDedupProcessor returns initialized DedupProcessor.
+func NewDedupProcessor(c DedupConfig) (*DedupProcessor, error) {
+	common, err := NewCommonProcessor(c.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &DedupProcessor{
+		CommonProcessor: common,
+		Config:          c,
+	}
+	return r, nil
+}
+
+// WriteHeader writes header columns
+func (r *DedupProcessor) WriteHeader() error {
+	c := r.Config
+
+	// read header if not read yet
+	if len(r.inputHeader) == 0 {
+		err := r.ReadTargetHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	r.outputHeader = make([]string, len(r.inputHeader))
+	copy(r.outputHeader, r.inputHeader)
+	if c.Mode == DedupModeCluster {
+		r.outputHeader = append(r.outputHeader,
+			c.Prefix+"cluster_id",
+			c.Prefix+"cluster_size",
+			c.Prefix+"is_duplicate",
+		)
+	}
+
+	// write to file
+	return r.w.Write(r.outputHeader)
+}
+
+// DoWithProgress processes with showing progress.
+func (r *DedupProcessor) DoWithProgress() error {
+	r.ShowProgress()
+
+	conf := r.Config
+	logger := conf.Logger
+	logger.Infof("DoWithProgress", "read lines...")
+
+	err := r.Do()
+	if err != nil {
+		logger.Errorf("DoWithProgress", "error on r.Do() err:[%s]", err.Error())
+		return err
+	}
+
+	logger.Infof("DoWithProgress", "finish process")
+	return nil
+}
+
+// Do finds near-duplicate rows and writes the result.
+func (r *DedupProcessor) Do() error {
+	defer r.Close()
+	c := r.Config
+	logger := c.Logger
+
+	clusters, err := r.GetClusters()
+	if err != nil {
+		return err
+	}
+
+	// read the input again to write the result
+	if err := r.r.Close(); err != nil {
+		return err
+	}
+	if err := r.SetReaderFromFile(c.Input); err != nil {
+		return err
+	}
+	if err := r.ReadHeader(); err != nil {
+		return err
+	}
+
+	sizes := make([]uint32, len(clusters.parent))
+	for i := range clusters.parent {
+		sizes[clusters.find(i)]++
+	}
+
+	dupCount := 0
+	for row := 0; ; row++ {
+		line, err := r.r.Read()
+		switch {
+		case err == io.EOF:
+			logger.Infof("Do", "Total Rows:%d Duplicates:%d", row, dupCount)
+			return nil
+		case err != nil:
+			logger.Errorf("Do", "r.r.Read() err:[%s]\n", err.Error())
+			return err
+		case row >= len(clusters.parent):
+			// the file is changed while processing
+			logger.Errorf("Do", "row size is changed from the first read: [%d]\n", len(clusters.parent))
+			return nil
+		}
+
+		root := clusters.find(row)
+		isDup := root != row
+		if isDup {
+			dupCount++
+		}
+
+		switch c.Mode {
+		case DedupModeRemove:
+			if isDup {
+				continue
+			}
+		default:
+			line = append(line,
+				strconv.Itoa(root+1),
+				strconv.Itoa(int(sizes[root])),
+				strconv.FormatBool(isDup),
+			)
+		}
+		if err := r.w.Write(line); err != nil {
+			logger.Errorf("Do", "r.w.Write() err:[%s]\n", err.Error())
+			return err
+		}
+	}
+}
+
+// maxBucketMembers is the maximum number of rows kept in each LSH bucket to compare with the later rows.
+const maxBucketMembers = 32
+
+// GetClusters reads lines and makes clusters of near-duplicate rows by MinHash and LSH.
+func (r *DedupProcessor) GetClusters() (clusters *unionFind, err error) {
+	c := r.Config
+	logger := c.Logger
+
+	store, err := newSignatureStore(c.HashSize)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			logger.Errorf("GetClusters", "store.Close() err:[%s]\n", err.Error())
+		}
+	}()
+
+	lastLineNo := 1
+	lastLineText := ""
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		logger.Errorf("GetClusters", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
+		clusters = nil
+		err = fmt.Errorf("unknown error occurred on Line:[%d] err:[%v]", lastLineNo, rec)
+	}()
+
+	hasher := newMinHasher(c.ShingleSize, c.HashSize, c.Seed)
+	// the rows of each bucket
+	buckets := make(map[uint64][]uint32, 1024)
+	clusters = &unionFind{}
+	for row := 0; ; row++ {
+		lastLineNo++
+		line, err := r.r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			logger.Errorf("GetClusters", "r.r.Read() err:[%s]\n", err.Error())
+			return nil, err
+		}
+
+		clusters.add()
+		lastLineText = line[r.columnIndex]
+		sig := hasher.signature(r.tok.GetTokenKeys(r.tokenizeLine(line).words))
+		if sig == nil {
+			// empty text is not treated as duplicate
+			continue
+		}
+		if err := store.put(row, sig); err != nil {
+			return nil, err
+		}
+
+		checked := make(map[int]struct{})
+		for _, key := range bandKeys(sig, c.BandSize) {
+			members := buckets[key]
+			if len(members) < maxBucketMembers {
+				buckets[key] = append(members, uint32(row))
+			}
+
+			for _, m := range members {
+				candidate := int(m)
+				if _, ok := checked[candidate]; ok {
+					continue
+				}
+				checked[candidate] = struct{}{}
+				// already in the same cluster
+				if clusters.find(candidate) == clusters.find(row) {
+					continue
+				}
+
+				other, err := store.get(candidate)
+				if err != nil {
+					return nil, err
+				}
+				if similarity(sig, other) >= c.Threshold {
+					clusters.union(candidate, row)
+				}
+			}
+		}
+	}
+	return clusters, nil
+}
@@ -0,0 +1,222 @@
+package ripper
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/evalphobia/go-jp-text-ripper/tokenizer"
+)
+
+// DoKWIC creates *KWICProcessor from config and run it.
+func DoKWIC(conf KWICConfig) error {
+	if err := conf.Init(); err != nil {
+		return err
+	}
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	conf.Logger.Infof("DoKWIC", "version:[%s] rev:[%s]", conf.Version, conf.Revision)
+	r, err := NewKWICProcessor(conf)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := r.WriteHeader(); err != nil {
+		return err
+	}
+
+	return r.DoWithProgress()
+}
+
+// KWICProcessor is struct for keyword-in-context concordance.
+type KWICProcessor struct {
+	*CommonProcessor
+	Config  KWICConfig
+	idIndex int
+}
+
+// NewKWICProcessor returns initialized KWICProcessor.
+func NewKWICProcessor(c KWICConfig) (*KWICProcessor, error) {
+	common, err := NewCommonProcessor(c.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &KWICProcessor{
+		CommonProcessor: common,
+		Config:          c,
+		idIndex:         -1,
+	}
+	return r, nil
+}
+
+// ReadHeader reads header columns and sets target column and id column.
+func (r *KWICProcessor) ReadHeader() error {
+	if err := r.ReadTargetHeader(); err != nil {
+		return err
+	}
+
+	c := r.Config
+	if c.IDColumn == "" {
+		return nil
+	}
+	r.idIndex = r.GetColumnIndex(c.IDColumn)
+	if r.idIndex < 0 {
+		return fmt.Errorf("cannnot find id column name in header: col:[%s] headers:[%+v]", c.IDColumn, r.inputHeader)
+	}
+	return nil
+}
+
+// WriteHeader writes header columns
+func (r *KWICProcessor) WriteHeader() error {
+	// read header if not read yet
+	if len(r.inputHeader) == 0 {
+		err := r.ReadHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	r.outputHeader = []string{"row"}
+	if r.idIndex >= 0 {
+		r.outputHeader = append(r.outputHeader, r.Config.IDColumn)
+	}
+	r.outputHeader = append(r.outputHeader,
+		"left",
+		"keyword",
+		"right",
+	)
+
+	// write to file
+	return r.w.Write(r.outputHeader)
+}
+
+// DoWithProgress processes with showing progress.
+func (r *KWICProcessor) DoWithProgress() error {
+	r.ShowProgress()
+
+	conf := r.Config
+	logger := conf.Logger
+	logger.Infof("DoWithProgress", "read lines...")
+
+	err := r.Do()
+	if err != nil {
+		logger.Errorf("DoWithProgress", "error on r.Do() err:[%s]", err.Error())
+		return err
+	}
+
+	logger.Infof("DoWithProgress", "finish process")
+	return nil
+}
+
+// Do searches the query from each lines and writes the hits with the context.
+func (r *KWICProcessor) Do() (err error) {
+	defer r.Close()
+	c := r.Config
+	logger := c.Logger
+	idx := r.columnIndex
+
+	lastLineNo := 1
+	lastLineText := ""
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		logger.Errorf("Do", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
+		err = fmt.Errorf("unknown error occurred on Line:[%d] err:[%v]", lastLineNo, rec)
+	}()
+
+	hitCount := 0
+	for rowNo := 1; ; rowNo++ {
+		lastLineNo++
+		line, err := r.r.Read()
+		switch {
+		case err == io.EOF:
+			logger.Infof("Do", "Total Hits:%d", hitCount)
+			return nil
+		case err != nil:
+			logger.Errorf("Do", "r.r.Read() err:[%s]\n", err.Error())
+			return err
+		}
+
+		lastLineText = line[idx]
+		tokens := r.tok.TokenizeAll(r.applyPreFilters(line[idx])).List
+		for i, t := range tokens {
+			if !r.isMatch(t) {
+				continue
+			}
+			hitCount++
+
+			left := joinSurface(tokens[maxInt(0, i-c.ContextSize):i])
+			right := joinSurface(tokens[i+1 : minInt(len(tokens), i+1+c.ContextSize)])
+			if c.ShowResult {
+				logger.Infof("Do", "#%d %s [%s] %s", rowNo, left, t.GetSurface(), right)
+			}
+
+			results := []string{strconv.Itoa(rowNo)}
+			switch {
+			case r.idIndex < 0:
+				// no id column
+			case r.idIndex < len(line):
+				results = append(results, line[r.idIndex])
+			default:
+				// keep the column position for the short line
+				results = append(results, "")
+			}
+			results = append(results, left, t.GetSurface(), right)
+			if err := r.w.Write(results); err != nil {
+				logger.Errorf("Do", "r.w.Write() err:[%s]\n", err.Error())
+				return err
+			}
+		}
+	}
+}
+
+// isMatch checks the token matches the query and the part of speech.
+func (r *KWICProcessor) isMatch(t *tokenizer.Token) bool {
+	c := r.Config
+	for _, p := range c.posList {
+		if !t.HasFeature(p) {
+			return false
+		}
+	}
+	if c.Query == "" {
+		return true
+	}
+
+	word := t.GetSurface()
+	if c.UseOriginalForm {
+		word = t.GetOriginalForm()
+	}
+	if c.queryRegexp != nil {
+		return c.queryRegexp.MatchString(word)
+	}
+	return word == c.Query
+}
+
+func joinSurface(tokens []*tokenizer.Token) string {
+	words := make([]string, len(tokens))
+	for i, t := range tokens {
+		words[i] = t.GetSurface()
+	}
+	return strings.Join(words, " ")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
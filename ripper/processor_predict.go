@@ -0,0 +1,132 @@
+package ripper
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// DoPredict creates *PredictProcessor from config and run it.
+func DoPredict(conf PredictConfig) error {
+	if err := conf.Init(); err != nil {
+		return err
+	}
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	conf.Logger.Infof("DoPredict", "version:[%s] rev:[%s]", conf.Version, conf.Revision)
+	r, err := NewPredictProcessor(conf)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := r.WriteHeader(); err != nil {
+		return err
+	}
+
+	return r.DoWithProgress()
+}
+
+// PredictProcessor is struct for predicting the label by Naive Bayes classifier.
+type PredictProcessor struct {
+	*CommonProcessor
+	Config PredictConfig
+}
+
+// NewPredictProcessor returns initialized PredictProcessor.
+func NewPredictProcessor(c PredictConfig) (*PredictProcessor, error) {
+	common, err := NewCommonProcessor(c.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &PredictProcessor{
+		CommonProcessor: common,
+		Config:          c,
+	}
+	return r, nil
+}
+
+// WriteHeader writes header columns
+func (r *PredictProcessor) WriteHeader() error {
+	c := r.Config
+
+	// read header if not read yet
+	if len(r.inputHeader) == 0 {
+		err := r.ReadTargetHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	r.outputHeader = make([]string, len(r.inputHeader), len(r.inputHeader)+2)
+	copy(r.outputHeader, r.inputHeader)
+	r.outputHeader = append(r.outputHeader, c.Prefix+"label", c.Prefix+"score")
+
+	// write to file
+	return r.w.Write(r.outputHeader)
+}
+
+// DoWithProgress processes with showing progress.
+func (r *PredictProcessor) DoWithProgress() error {
+	r.ShowProgress()
+
+	conf := r.Config
+	logger := conf.Logger
+	logger.Infof("DoWithProgress", "read and write lines...")
+
+	err := r.Do()
+	if err != nil {
+		logger.Errorf("DoWithProgress", "error on r.Do() err:[%s]", err.Error())
+		return err
+	}
+
+	logger.Infof("DoWithProgress", "finish process")
+	return nil
+}
+
+// Do processes each lines, predicts the label and write it.
+func (r *PredictProcessor) Do() (err error) {
+	defer r.Close()
+	c := r.Config
+	logger := c.Logger
+	model := c.NaiveBayes
+
+	lastLineNo := 1
+	lastLineText := ""
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		logger.Errorf("Do", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
+		err = fmt.Errorf("unknown error occurred on Line:[%d] err:[%v]", lastLineNo, rec)
+	}()
+
+	for {
+		lastLineNo++
+		line, err := r.r.Read()
+		switch {
+		case err == io.EOF:
+			// end of file
+			return nil
+		case err != nil:
+			logger.Errorf("Do", "r.r.Read() err:[%s]\n", err.Error())
+			return err
+		}
+
+		lastLineText = line[r.columnIndex]
+		label, score := model.Predict(r.tok.GetTokenKeys(r.tokenizeLine(line).words))
+		if c.ShowResult {
+			logger.Infof("Do", "[%s] %.05f %s", label, score, lastLineText)
+		}
+
+		results := append(line, label, strconv.FormatFloat(score, 'f', 5, 64))
+		if err := r.w.Write(results); err != nil {
+			logger.Errorf("Do", "r.w.Write() err:[%s]\n", err.Error())
+			return err
+		}
+	}
+}
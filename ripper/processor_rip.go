@@ -8,6 +8,8 @@ import (
 	"unicode/utf8"
 
 	"github.com/evalphobia/go-jp-text-ripper/log"
+	"github.com/evalphobia/go-jp-text-ripper/tokenizer"
+	"github.com/evalphobia/go-jp-text-ripper/writer"
 )
 
 // DoRip creates *RipProcessor from config and run it.
@@ -37,14 +39,20 @@ func DoRip(conf RipConfig) error {
 // RipProcessor is struct for putting spaces between words.
 type RipProcessor struct {
 	*CommonProcessor
-	Config    RipConfig
-	quoteCols []string
-	quoteIdx  []int
+	Config     RipConfig
+	quoteCols  []string
+	quoteIdx   []int
+	labelIndex int
 }
 
 // NewRipProcessor returns initialized RipProcessor.
 func NewRipProcessor(c RipConfig) (*RipProcessor, error) {
-	common, err := NewCommonProcessor(c.CommonConfig)
+	commonConf := c.CommonConfig
+	if c.UsePlainFormat() {
+		// plain text writer is set after
+		commonConf.Output = ""
+	}
+	common, err := NewCommonProcessor(commonConf)
 	if err != nil {
 		return nil, err
 	}
@@ -52,6 +60,14 @@ func NewRipProcessor(c RipConfig) (*RipProcessor, error) {
 	r := &RipProcessor{
 		CommonProcessor: common,
 		Config:          c,
+		labelIndex:      -1,
+	}
+	if c.UsePlainFormat() && c.Output != "" {
+		r.w, err = writer.NewPlainFromFile(c.Output)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
 	}
 	if len(c.Quotes) != 0 {
 		r.SetQuoteColumns(c.Quotes)
@@ -71,12 +87,33 @@ func (r *RipProcessor) SetQuoteColumns(cols []string) {
 // ReadHeader reads header columns and sets target column.
 func (r *RipProcessor) ReadHeader() error {
 	c := r.Config
+	var err error
 	switch {
 	case c.ColumnNumber > 0:
-		return r.CommonProcessor.ReadHeaderWithIndex(c.ColumnNumber - 1)
+		err = r.CommonProcessor.ReadHeaderWithIndex(c.ColumnNumber - 1)
 	default:
-		return r.readHeaderByName(c.Column)
+		err = r.readHeaderByName(c.Column)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, p := range r.plugins {
+		for _, col := range p.Columns {
+			if r.GetColumnIndex(col) < 0 {
+				return fmt.Errorf("cannnot find column name of the plugin in header: plugin:[%s] col:[%s] headers:[%+v]", p.Title, col, r.inputHeader)
+			}
+		}
+	}
+
+	if c.LabelColumn == "" {
+		return nil
 	}
+	r.labelIndex = r.GetColumnIndex(c.LabelColumn)
+	if r.labelIndex < 0 {
+		return fmt.Errorf("cannnot find label column name in header: col:[%s] headers:[%+v]", c.LabelColumn, r.inputHeader)
+	}
+	return nil
 }
 
 // readHeaderByName reads header columns and check target column is existed or not.
@@ -136,6 +173,9 @@ func (r *RipProcessor) WriteHeader() error {
 	}
 
 	extraHeaders := []string{colWordCount, colNonWordCount, colCharCount}
+	if c.UseSentenceUnit() {
+		extraHeaders = append(extraHeaders, c.Prefix+"row", c.Prefix+"sentence_index")
+	}
 	for _, p := range r.plugins {
 		extraHeaders = append(extraHeaders, c.Prefix+p.Title)
 	}
@@ -144,6 +184,10 @@ func (r *RipProcessor) WriteHeader() error {
 	}
 
 	r.outputHeader = append(opHeader, extraHeaders...)
+	if c.UsePlainFormat() {
+		// plain text does not have header
+		return nil
+	}
 
 	// write to file
 	return r.w.Write(r.outputHeader)
@@ -182,7 +226,6 @@ func (r *RipProcessor) Do() error {
 	}
 
 	idx := r.columnIndex
-	tok := r.tok
 
 	lastLineNo := 1
 	lastLineText := ""
@@ -194,7 +237,7 @@ func (r *RipProcessor) Do() error {
 		logger.Errorf("Do", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
 	}()
 
-	for {
+	for rowNo := 1; ; rowNo++ {
 		lastLineNo++
 		line, err := r.r.Read()
 		switch {
@@ -206,64 +249,151 @@ func (r *RipProcessor) Do() error {
 			return err
 		}
 
-		text := &TextData{}
-
-		// tokenize text
 		lastLineText = line[idx]
-		text.raw = line[idx]
-		text.normalized = r.applyPreFilters(text.raw)
-		text.words, text.nonWords = tok.Tokenize(text.normalized)
-		if err != nil {
-			return err
+		if !c.UseSentenceUnit() {
+			if err := r.processLine(line, line[idx], rowNo, 0); err != nil {
+				return err
+			}
+			continue
 		}
 
-		if c.Debug {
-			showDebug(logger, text)
+		sentences := tokenizer.SplitSentences(line[idx])
+		if len(sentences) == 0 {
+			// keep the row of empty text as one empty sentence (dropped by DropEmpty)
+			sentences = []string{""}
 		}
+		for i, sentence := range sentences {
+			sentenceLine := make([]string, len(line))
+			copy(sentenceLine, line)
+			sentenceLine[idx] = sentence
+			if err := r.processLine(sentenceLine, sentence, rowNo, i+1); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// processLine tokenizes the raw text and writes the result line.
+// sentenceNo is used for sentence unit (first=1).
+func (r *RipProcessor) processLine(line []string, raw string, rowNo, sentenceNo int) error {
+	c := r.Config
+	logger := c.Logger
+	idx := r.columnIndex
+
+	text := &TextData{
+		header: r.inputHeader,
+		line:   line,
+		tok:    r.tok,
+	}
+
+	// tokenize text
+	text.raw = raw
+	text.normalized = r.applyPreFilters(text.raw)
+	text.words, text.nonWords = r.tok.Tokenize(text.normalized)
+
+	if c.Debug {
+		showDebug(logger, text)
+	}
 
-		// create result line
-		words := text.words.GetWords()
-		wordCount := strconv.Itoa(len(words))
-		nonWordCount := strconv.Itoa(len(text.nonWords.GetWords()))
-		textLen := strconv.Itoa(utf8.RuneCountInString(text.raw))
-		wordLine := strings.Join(words, " ")
-		if c.ShowResult {
-			logger.Infof("Do", wordLine)
+	// create result line
+	words := r.getOutputWords(text.words)
+	wordCount := strconv.Itoa(len(words))
+	nonWordCount := strconv.Itoa(len(text.nonWords.GetWords()))
+	textLen := strconv.Itoa(utf8.RuneCountInString(text.raw))
+	wordLine := strings.Join(words, " ")
+	if c.ShowResult {
+		logger.Infof("Do", wordLine)
+	}
+	if c.DropEmpty && wordLine == "" {
+		return nil
+	}
+	if c.UsePlainFormat() {
+		return r.writePlain(line, words)
+	}
+
+	var results []string
+	if !c.ReplaceText {
+		results = append(results, wordLine)
+	}
+	results = append(results, wordCount, nonWordCount, textLen)
+	if c.UseSentenceUnit() {
+		results = append(results, strconv.Itoa(rowNo), strconv.Itoa(sentenceNo))
+	}
+
+	// plugins read the input line, so replace the target column after them
+	results = r.applyPlugins(results, text)
+	switch {
+	case c.ReplaceText:
+		line[idx] = wordLine
+	case c.TextMask != nil:
+		line[idx] = c.TextMask(text)
+	}
+	results = r.applyPostFilters(results, line)
+
+	// quoting
+	for _, i := range r.quoteIdx {
+		line[i] = `"` + line[i] + `"`
+	}
+
+	// write result line
+	results = append(line, results...)
+	err := r.w.Write(results)
+	if err != nil {
+		logger.Errorf("Do", "r.w.Write() err:[%s]\n", err.Error())
+		return err
+	}
+	return nil
+}
+
+// getOutputWords returns words converted by the output options.
+func (r *RipProcessor) getOutputWords(list *tokenizer.TokenList) []string {
+	c := r.Config
+	words := list.GetWords()
+	if !c.UseLowercase && c.NumberMask == "" && c.WordMask == nil {
+		return words
+	}
+
+	for i, w := range words {
+		if c.WordMask != nil {
+			if masked, ok := c.WordMask(list.List[i]); ok {
+				words[i] = masked
+				continue
+			}
 		}
-		if c.DropEmpty && wordLine == "" {
+		if masked, ok := r.tok.MaskNumber(list.List[i]); ok {
+			words[i] = masked
 			continue
 		}
-
-		var results []string
-		if c.ReplaceText {
-			line[idx] = wordLine
-		} else {
-			results = append(results, wordLine)
+		if c.UseLowercase {
+			words[i] = strings.ToLower(w)
 		}
-		results = append(results, wordCount, nonWordCount, textLen)
+	}
+	return words
+}
 
-		results = r.applyPlugins(results, text)
-		results = r.applyPostFilters(results, line)
+// writePlain writes words as plain text (with label for fasttext).
+func (r *RipProcessor) writePlain(line, words []string) error {
+	c := r.Config
+	logger := c.Logger
 
-		// quoting
-		for _, i := range r.quoteIdx {
-			line[i] = `"` + line[i] + `"`
+	results := words
+	if c.Format == FormatFastText && r.labelIndex >= 0 && r.labelIndex < len(line) {
+		if label := strings.Join(strings.Fields(line[r.labelIndex]), "_"); label != "" {
+			results = append([]string{fastTextLabelPrefix + label}, words...)
 		}
+	}
 
-		// write result line
-		results = append(line, results...)
-		err = r.w.Write(results)
-		if err != nil {
-			logger.Errorf("Do", "r.w.Write() err:[%s]\n", err.Error())
-			return err
-		}
+	err := r.w.Write(results)
+	if err != nil {
+		logger.Errorf("writePlain", "r.w.Write() err:[%s]\n", err.Error())
 	}
+	return err
 }
 
 // doGetRankStopWord gets word frequency for the stop words.
 func (r *RipProcessor) doGetRankStopWord() (RankResult, error) {
 	c := r.Config
-	rp, err := NewRankProcessor(RankConfig{
+	return GetCorpusRank(RankConfig{
 		CommonConfig: c.CommonConfig,
 		TopNumber:    c.StopWordTopNumber,
 		TopPercent:   c.StopWordTopPercent,
@@ -271,11 +401,6 @@ func (r *RipProcessor) doGetRankStopWord() (RankResult, error) {
 		LastPercent:  c.StopWordLastPercent,
 		UseUnique:    c.UseStopWordUnique,
 	})
-	if err != nil {
-		return RankResult{}, err
-	}
-
-	return rp.GetRank()
 }
 
 func showDebug(logger log.Logger, text *TextData) {
@@ -0,0 +1,257 @@
+package ripper
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/evalphobia/go-jp-text-ripper/writer"
+)
+
+// DoTopics creates *TopicsProcessor from config and run it.
+func DoTopics(conf TopicsConfig) error {
+	if err := conf.Init(); err != nil {
+		return err
+	}
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	conf.Logger.Infof("DoTopics", "version:[%s] rev:[%s]", conf.Version, conf.Revision)
+	r, err := NewTopicsProcessor(conf)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := r.WriteHeader(); err != nil {
+		return err
+	}
+
+	return r.DoWithProgress()
+}
+
+// TopicsProcessor is struct for topic modeling.
+type TopicsProcessor struct {
+	*CommonProcessor
+	Config TopicsConfig
+}
+
+// NewTopicsProcessor returns initialized TopicsProcessor.
+func NewTopicsProcessor(c TopicsConfig) (*TopicsProcessor, error) {
+	common, err := NewCommonProcessor(c.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &TopicsProcessor{
+		CommonProcessor: common,
+		Config:          c,
+	}
+	return r, nil
+}
+
+// WriteHeader writes header columns
+func (r *TopicsProcessor) WriteHeader() error {
+	c := r.Config
+
+	// read header if not read yet
+	if len(r.inputHeader) == 0 {
+		err := r.ReadTargetHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	r.outputHeader = make([]string, len(r.inputHeader), len(r.inputHeader)+c.TopicSize+1)
+	copy(r.outputHeader, r.inputHeader)
+	r.outputHeader = append(r.outputHeader, c.Prefix+"topic")
+	for i := 0; i < c.TopicSize; i++ {
+		r.outputHeader = append(r.outputHeader, c.Prefix+"topic_"+strconv.Itoa(i))
+	}
+
+	// write to file
+	return r.w.Write(r.outputHeader)
+}
+
+// DoWithProgress processes with showing progress.
+func (r *TopicsProcessor) DoWithProgress() error {
+	r.ShowProgress()
+
+	conf := r.Config
+	logger := conf.Logger
+	logger.Infof("DoWithProgress", "read lines...")
+
+	err := r.Do()
+	if err != nil {
+		logger.Errorf("DoWithProgress", "error on r.Do() err:[%s]", err.Error())
+		return err
+	}
+
+	logger.Infof("DoWithProgress", "finish process")
+	return nil
+}
+
+// Do trains topic model and writes the results.
+func (r *TopicsProcessor) Do() error {
+	defer r.Close()
+	c := r.Config
+	if c.UseRankingForStopWord() {
+		rank, err := GetCorpusRank(RankConfig{
+			CommonConfig: c.CommonConfig,
+			TopNumber:    c.StopWordTopNumber,
+			TopPercent:   c.StopWordTopPercent,
+			LastNumber:   c.StopWordLastNumber,
+			LastPercent:  c.StopWordLastPercent,
+			UseUnique:    c.UseStopWordUnique,
+		})
+		if err != nil {
+			return err
+		}
+		r.tok.AddStopWords(rank.GetTopWords()...)
+		r.tok.AddStopWords(rank.GetLastWords()...)
+	}
+
+	model, err := r.Train()
+	if err != nil {
+		return err
+	}
+
+	if err := r.outputTopicWords(model); err != nil {
+		return err
+	}
+	return r.outputDocumentTopics(model)
+}
+
+// Train reads lines and trains LDA model.
+func (r *TopicsProcessor) Train() (model *ldaModel, err error) {
+	c := r.Config
+	logger := c.Logger
+
+	lastLineNo := 1
+	lastLineText := ""
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		logger.Errorf("Train", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
+		model = nil
+		err = fmt.Errorf("unknown error occurred on Line:[%d] err:[%v]", lastLineNo, rec)
+	}()
+
+	model = newLDAModel(c.TopicSize, c.Alpha, c.Beta, c.Seed)
+	for {
+		lastLineNo++
+		line, err := r.r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			logger.Errorf("Train", "r.r.Read() err:[%s]\n", err.Error())
+			return nil, err
+		}
+
+		lastLineText = line[r.columnIndex]
+		model.addDocument(r.tok.GetTokenKeys(r.tokenizeLine(line).words))
+	}
+
+	logger.Infof("Train", "documents:[%d] vocabulary:[%d] topics:[%d]", len(model.docs), len(model.vocab), c.TopicSize)
+	model.init()
+	interval := c.Iterations / 10
+	for i := 1; i <= c.Iterations; i++ {
+		model.iterate()
+		if interval > 0 && i%interval == 0 {
+			logger.Infof("Train", "iteration: %d/%d", i, c.Iterations)
+		}
+	}
+	return model, nil
+}
+
+// outputTopicWords writes top words of each topic.
+func (r *TopicsProcessor) outputTopicWords(model *ldaModel) error {
+	c := r.Config
+	logger := c.Logger
+
+	w := writer.NewDummy()
+	if c.TopicOutput != "" {
+		var err error
+		w, err = writer.NewFromFile(c.TopicOutput)
+		if err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if err := w.Close(); err != nil {
+			logger.Errorf("outputTopicWords", "w.Close() err:[%s]\n", err.Error())
+		}
+	}()
+
+	if err := w.Write([]string{"topic", "rank", "word", "prob"}); err != nil {
+		return err
+	}
+	for t := 0; t < c.TopicSize; t++ {
+		for i, v := range model.topWords(t, c.TopicWordNumber) {
+			if c.ShowResult {
+				logger.Infof("output", "[topic_%d] #%d %s (%.05f)", t, i+1, v.word, v.prob)
+			}
+			err := w.Write([]string{
+				strconv.Itoa(t),
+				strconv.Itoa(i + 1),
+				v.word,
+				strconv.FormatFloat(v.prob, 'f', 5, 64),
+			})
+			if err != nil {
+				logger.Errorf("outputTopicWords", "w.Write() err:[%s]\n", err.Error())
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// outputDocumentTopics reads the input again and writes topic distribution of each row.
+func (r *TopicsProcessor) outputDocumentTopics(model *ldaModel) error {
+	c := r.Config
+	logger := c.Logger
+	if c.Output == "" {
+		return nil
+	}
+
+	if err := r.r.Close(); err != nil {
+		return err
+	}
+	if err := r.SetReaderFromFile(c.Input); err != nil {
+		return err
+	}
+	if err := r.ReadHeader(); err != nil {
+		return err
+	}
+
+	for d := 0; d < len(model.docs); d++ {
+		line, err := r.r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			logger.Errorf("outputDocumentTopics", "r.r.Read() err:[%s]\n", err.Error())
+			return err
+		}
+
+		dist := model.docTopicDistribution(d)
+		best := 0
+		for t, p := range dist {
+			if p > dist[best] {
+				best = t
+			}
+		}
+
+		results := append(line, strconv.Itoa(best))
+		for _, p := range dist {
+			results = append(results, strconv.FormatFloat(p, 'f', 5, 64))
+		}
+		if err := r.w.Write(results); err != nil {
+			logger.Errorf("outputDocumentTopics", "r.w.Write() err:[%s]\n", err.Error())
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package ripper
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+)
+
+// DoTrain creates *TrainProcessor from config and run it.
+func DoTrain(conf TrainConfig) error {
+	if err := conf.Init(); err != nil {
+		return err
+	}
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	conf.Logger.Infof("DoTrain", "version:[%s] rev:[%s]", conf.Version, conf.Revision)
+	r, err := NewTrainProcessor(conf)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := r.WriteHeader(); err != nil {
+		return err
+	}
+
+	return r.DoWithProgress()
+}
+
+// TrainProcessor is struct for training Naive Bayes classifier.
+type TrainProcessor struct {
+	*CommonProcessor
+	Config     TrainConfig
+	labelIndex int
+}
+
+// labeledDocument is tokenized words with the label.
+type labeledDocument struct {
+	label string
+	words []string
+}
+
+// NewTrainProcessor returns initialized TrainProcessor.
+func NewTrainProcessor(c TrainConfig) (*TrainProcessor, error) {
+	common, err := NewCommonProcessor(c.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &TrainProcessor{
+		CommonProcessor: common,
+		Config:          c,
+		labelIndex:      -1,
+	}
+	return r, nil
+}
+
+// WriteHeader reads header columns.
+// Header of the evaluation result is written after training, because it depends on the labels.
+func (r *TrainProcessor) WriteHeader() error {
+	c := r.Config
+	if len(r.inputHeader) != 0 {
+		return nil
+	}
+
+	if err := r.ReadTargetHeader(); err != nil {
+		return err
+	}
+	r.labelIndex = r.GetColumnIndex(c.LabelColumn)
+	if r.labelIndex < 0 {
+		return fmt.Errorf("cannnot find label column name in header: col:[%s] headers:[%+v]", c.LabelColumn, r.inputHeader)
+	}
+	return nil
+}
+
+// DoWithProgress processes with showing progress.
+func (r *TrainProcessor) DoWithProgress() error {
+	r.ShowProgress()
+
+	conf := r.Config
+	logger := conf.Logger
+	logger.Infof("DoWithProgress", "read lines...")
+
+	err := r.Do()
+	if err != nil {
+		logger.Errorf("DoWithProgress", "error on r.Do() err:[%s]", err.Error())
+		return err
+	}
+
+	logger.Infof("DoWithProgress", "finish process")
+	return nil
+}
+
+// Do trains the model, evaluates it and saves it.
+func (r *TrainProcessor) Do() error {
+	defer r.Close()
+	c := r.Config
+	logger := c.Logger
+
+	docs, err := r.readDocuments()
+	if err != nil {
+		return err
+	}
+
+	if c.UseEvaluation() {
+		report := r.crossValidate(docs)
+		if err := r.outputReport(report); err != nil {
+			return err
+		}
+	}
+
+	model := r.train(docs)
+	logger.Infof("Do", "documents:[%d] labels:[%d] vocabulary:[%d]", len(docs), len(model.Labels), len(model.WordCount))
+	if err := model.Save(c.ModelOutput); err != nil {
+		logger.Errorf("Do", "model.Save() err:[%s]\n", err.Error())
+		return err
+	}
+	logger.Infof("Do", "saved model: [%s]", c.ModelOutput)
+	return nil
+}
+
+// readDocuments reads lines and tokenizes them.
+func (r *TrainProcessor) readDocuments() (docs []labeledDocument, err error) {
+	c := r.Config
+	logger := c.Logger
+
+	lastLineNo := 1
+	lastLineText := ""
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		logger.Errorf("readDocuments", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
+		docs = nil
+		err = fmt.Errorf("unknown error occurred on Line:[%d] err:[%v]", lastLineNo, rec)
+	}()
+
+	for {
+		lastLineNo++
+		line, err := r.r.Read()
+		if err == io.EOF {
+			return docs, nil
+		} else if err != nil {
+			logger.Errorf("readDocuments", "r.r.Read() err:[%s]\n", err.Error())
+			return nil, err
+		}
+
+		lastLineText = line[r.columnIndex]
+		label := line[r.labelIndex]
+		if label == "" {
+			// skip unlabeled row
+			continue
+		}
+		docs = append(docs, labeledDocument{
+			label: label,
+			words: r.tok.GetTokenKeys(r.tokenizeLine(line).words),
+		})
+	}
+}
+
+// train trains Naive Bayes model from the documents.
+func (r *TrainProcessor) train(docs []labeledDocument) *NaiveBayesModel {
+	c := r.Config
+	model := newNaiveBayesModel(c.Alpha, NewTokenizerSetting(c.CommonConfig, c.Filter))
+	model.Version = c.Version
+	for _, d := range docs {
+		model.Add(d.label, d.words)
+	}
+	return model
+}
+
+// crossValidate evaluates the model by k-fold cross validation.
+func (r *TrainProcessor) crossValidate(docs []labeledDocument) *classificationReport {
+	c := r.Config
+	logger := c.Logger
+
+	folds := make([]int, len(docs))
+	rnd := rand.New(rand.NewSource(c.Seed)) // #nosec G404
+	for i, pos := range rnd.Perm(len(docs)) {
+		folds[pos] = i % c.KFold
+	}
+
+	report := newClassificationReport()
+	for k := 0; k < c.KFold; k++ {
+		trainDocs := make([]labeledDocument, 0, len(docs))
+		for i, d := range docs {
+			if folds[i] != k {
+				trainDocs = append(trainDocs, d)
+			}
+		}
+
+		model := r.train(trainDocs)
+		for i, d := range docs {
+			if folds[i] != k {
+				continue
+			}
+			predicted, _ := model.Predict(d.words)
+			report.add(d.label, predicted)
+		}
+		logger.Infof("crossValidate", "fold: %d/%d", k+1, c.KFold)
+	}
+	return report
+}
+
+// outputReport writes precision, recall, f1 and confusion matrix of each label.
+func (r *TrainProcessor) outputReport(report *classificationReport) error {
+	c := r.Config
+	logger := c.Logger
+
+	header := []string{"label", "precision", "recall", "f1", "support"}
+	for _, l := range report.labels {
+		header = append(header, "predicted_"+l)
+	}
+	if err := r.w.Write(header); err != nil {
+		logger.Errorf("outputReport", "r.w.Write() err:[%s]\n", err.Error())
+		return err
+	}
+
+	logger.Infof("outputReport", "accuracy: %.05f", report.accuracy())
+	for _, actual := range report.labels {
+		precision, recall, f1, support := report.metrics(actual)
+		if c.ShowResult {
+			logger.Infof("outputReport", "[%s] precision:%.05f recall:%.05f f1:%.05f support:%d", actual, precision, recall, f1, support)
+		}
+
+		results := []string{
+			actual,
+			strconv.FormatFloat(precision, 'f', 5, 64),
+			strconv.FormatFloat(recall, 'f', 5, 64),
+			strconv.FormatFloat(f1, 'f', 5, 64),
+			strconv.Itoa(support),
+		}
+		for _, predicted := range report.labels {
+			results = append(results, strconv.Itoa(report.confusion[actual][predicted]))
+		}
+		if err := r.w.Write(results); err != nil {
+			logger.Errorf("outputReport", "r.w.Write() err:[%s]\n", err.Error())
+			return err
+		}
+	}
+	return nil
+}
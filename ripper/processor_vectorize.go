@@ -0,0 +1,350 @@
+package ripper
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/evalphobia/go-jp-text-ripper/reader"
+	"github.com/evalphobia/go-jp-text-ripper/writer"
+)
+
+// DoVectorize creates *VectorizeProcessor from config and run it.
+func DoVectorize(conf VectorizeConfig) error {
+	if err := conf.Init(); err != nil {
+		return err
+	}
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	conf.Logger.Infof("DoVectorize", "version:[%s] rev:[%s]", conf.Version, conf.Revision)
+	r, err := NewVectorizeProcessor(conf)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := r.ReadHeader(); err != nil {
+		return err
+	}
+
+	return r.DoWithProgress()
+}
+
+// VectorizeProcessor is struct for creating bag-of-words matrix.
+type VectorizeProcessor struct {
+	*CommonProcessor
+	Config     VectorizeConfig
+	labelIndex int
+}
+
+// NewVectorizeProcessor returns initialized VectorizeProcessor.
+func NewVectorizeProcessor(c VectorizeConfig) (*VectorizeProcessor, error) {
+	// matrix is written by SparseWriter.
+	common := c.CommonConfig
+	common.Output = ""
+	cp, err := NewCommonProcessor(common)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &VectorizeProcessor{
+		CommonProcessor: cp,
+		Config:          c,
+		labelIndex:      -1,
+	}
+	return r, nil
+}
+
+// ReadHeader reads header columns and sets target column and label column.
+func (r *VectorizeProcessor) ReadHeader() error {
+	if err := r.ReadTargetHeader(); err != nil {
+		return err
+	}
+
+	c := r.Config
+	if c.LabelColumn == "" {
+		return nil
+	}
+	r.labelIndex = r.GetColumnIndex(c.LabelColumn)
+	if r.labelIndex < 0 {
+		return fmt.Errorf("cannnot find label column name in header: col:[%s] headers:[%+v]", c.LabelColumn, r.inputHeader)
+	}
+	return nil
+}
+
+// DoWithProgress processes with showing progress.
+func (r *VectorizeProcessor) DoWithProgress() error {
+	r.ShowProgress()
+
+	conf := r.Config
+	logger := conf.Logger
+	logger.Infof("DoWithProgress", "read lines...")
+
+	err := r.Do()
+	if err != nil {
+		logger.Errorf("DoWithProgress", "error on r.Do() err:[%s]", err.Error())
+		return err
+	}
+
+	logger.Infof("DoWithProgress", "finish process")
+	return nil
+}
+
+// Do creates vocabulary and writes the matrix.
+func (r *VectorizeProcessor) Do() error {
+	defer r.Close()
+	c := r.Config
+	logger := c.Logger
+
+	vocab, err := r.GetVocabulary()
+	if err != nil {
+		return err
+	}
+	logger.Infof("Do", "Vocabulary Size:%d", vocab.Size())
+
+	if c.VocabularyOutput != "" {
+		if err := vocab.Save(c.VocabularyOutput); err != nil {
+			return err
+		}
+	}
+	return r.writeMatrix(vocab)
+}
+
+// GetVocabulary loads saved vocabulary or builds vocabulary from the input.
+func (r *VectorizeProcessor) GetVocabulary() (*Vocabulary, error) {
+	c := r.Config
+	if c.Vocabulary != "" {
+		return LoadVocabulary(c.Vocabulary)
+	}
+
+	rp, err := NewRankProcessor(c.getRankConfig())
+	if err != nil {
+		return nil, err
+	}
+	defer rp.Close()
+
+	if err := rp.ReadHeader(); err != nil {
+		return nil, err
+	}
+	counter, err := rp.countAll()
+	if err != nil {
+		return nil, err
+	}
+	return newVocabularyFromRank(counter.result(ScoreCount), c.MinDF, c.MaxDF, c.MaxFeatures), nil
+}
+
+func (r *VectorizeProcessor) writeMatrix(vocab *Vocabulary) (err error) {
+	c := r.Config
+	logger := c.Logger
+
+	sw, err := writer.NewSparseFromFile(c.Output, c.Format, vocab.Size())
+	if err != nil {
+		return err
+	}
+
+	lastLineNo := 1
+	lastLineText := ""
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		logger.Errorf("writeMatrix", "unknown error occurred on Line:[%d] Text:[%s]\n", lastLineNo, lastLineText)
+		// close the writer to remove the temporary file
+		_ = sw.Close()
+		err = fmt.Errorf("unknown error occurred on Line:[%d] err:[%v]", lastLineNo, rec)
+	}()
+
+	for {
+		lastLineNo++
+		line, err := r.r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			logger.Errorf("writeMatrix", "r.r.Read() err:[%s]\n", err.Error())
+			_ = sw.Close()
+			return err
+		}
+
+		lastLineText = line[r.columnIndex]
+		text := r.tokenizeLine(line)
+		label := ""
+		if r.labelIndex >= 0 && r.labelIndex < len(line) {
+			label = line[r.labelIndex]
+		}
+		if err := sw.WriteRow(label, vocab.vectorize(r.tok.GetTokenKeys(text.words), c.Weight)); err != nil {
+			logger.Errorf("writeMatrix", "sw.WriteRow() err:[%s]\n", err.Error())
+			_ = sw.Close()
+			return err
+		}
+	}
+	return sw.Close()
+}
+
+// Vocabulary is word list for the features of the matrix.
+type Vocabulary struct {
+	Words []VocabularyWord
+	index map[string]int
+}
+
+// VocabularyWord is a word of the vocabulary.
+type VocabularyWord struct {
+	Word string
+	DF   int
+	IDF  float64
+}
+
+func newVocabularyFromRank(rank RankResult, minDF int, maxDF float64, maxFeatures int) *Vocabulary {
+	docCount := float64(rank.DocCount)
+	words := make([]VocabularyWord, 0, len(rank.List))
+	for _, v := range rank.List {
+		if maxFeatures > 0 && len(words) >= maxFeatures {
+			break
+		}
+		if v.df < minDF {
+			continue
+		}
+		if docCount > 0 && float64(v.df)/docCount > maxDF {
+			continue
+		}
+		words = append(words, VocabularyWord{
+			Word: v.word,
+			DF:   v.df,
+			IDF:  v.idf,
+		})
+	}
+	return newVocabulary(words)
+}
+
+func newVocabulary(words []VocabularyWord) *Vocabulary {
+	index := make(map[string]int, len(words))
+	for i, w := range words {
+		index[w.Word] = i
+	}
+	return &Vocabulary{
+		Words: words,
+		index: index,
+	}
+}
+
+// LoadVocabulary loads saved vocabulary from file.
+func LoadVocabulary(path string) (*Vocabulary, error) {
+	r, err := reader.NewFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	// skip header
+	if _, err := r.Read(); err != nil {
+		return nil, err
+	}
+
+	var words []VocabularyWord
+	for {
+		line, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if len(line) < 4 {
+			return nil, fmt.Errorf("invalid vocabulary line: [%+v]", line)
+		}
+
+		df, err := strconv.Atoi(line[2])
+		if err != nil {
+			return nil, err
+		}
+		idf, err := strconv.ParseFloat(line[3], 64)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, VocabularyWord{
+			Word: line[1],
+			DF:   df,
+			IDF:  idf,
+		})
+	}
+	return newVocabulary(words), nil
+}
+
+// Save writes the vocabulary into file.
+func (v *Vocabulary) Save(path string) error {
+	w, err := writer.NewFromFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := w.Write([]string{"index", "word", "df", "idf"}); err != nil {
+		_ = w.Close()
+		return err
+	}
+	for i, word := range v.Words {
+		err := w.Write([]string{
+			strconv.Itoa(i),
+			word.Word,
+			strconv.Itoa(word.DF),
+			strconv.FormatFloat(word.IDF, 'f', 8, 64),
+		})
+		if err != nil {
+			_ = w.Close()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// Size returns word size of the vocabulary.
+func (v *Vocabulary) Size() int {
+	return len(v.Words)
+}
+
+// vectorize converts words into the sparse row.
+func (v *Vocabulary) vectorize(words []string, weight string) []writer.SparseValue {
+	counts := make(map[int]int, len(words))
+	for _, w := range words {
+		if i, ok := v.index[w]; ok {
+			counts[i]++
+		}
+	}
+
+	row := make([]writer.SparseValue, 0, len(counts))
+	for i, count := range counts {
+		val := float64(count)
+		switch weight {
+		case WeightBinary:
+			val = 1
+		case WeightTFIDF:
+			val *= v.Words[i].IDF
+		}
+		row = append(row, writer.SparseValue{
+			Index: i,
+			Value: val,
+		})
+	}
+	sort.Slice(row, func(i, j int) bool {
+		return row[i].Index < row[j].Index
+	})
+
+	// l2 normalization for tf-idf
+	if weight == WeightTFIDF {
+		norm := 0.0
+		for _, val := range row {
+			norm += val.Value * val.Value
+		}
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for i := range row {
+				row[i].Value /= norm
+			}
+		}
+	}
+	return row
+}
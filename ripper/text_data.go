@@ -8,6 +8,11 @@ type TextData struct {
 	normalized string
 	words      *tokenizer.TokenList
 	nonWords   *tokenizer.TokenList
+	sentences  []string
+	allTokens  *tokenizer.TokenList
+	header     []string
+	line       []string
+	tok        *tokenizer.Tokenizer
 
 	Optional string // optional field for plugins
 }
@@ -27,7 +32,71 @@ func (t *TextData) GetWords() *tokenizer.TokenList {
 	return t.words
 }
 
+// GetWordKeys returns the keys of word tokens for matching and ranking (e.g. folded by KeyFilters).
+func (t *TextData) GetWordKeys() []string {
+	if t.tok == nil {
+		return t.words.GetWords()
+	}
+	return t.tok.GetTokenKeys(t.words)
+}
+
 // GetNonWords returns non-word tokens
 func (t *TextData) GetNonWords() *tokenizer.TokenList {
 	return t.nonWords
 }
+
+// GetAllTokens returns both of word and non-word tokens in order of the text
+func (t *TextData) GetAllTokens() *tokenizer.TokenList {
+	if t.allTokens != nil {
+		return t.allTokens
+	}
+
+	words, nonWords := t.words.List, t.nonWords.List
+	list := make([]*tokenizer.Token, 0, len(words)+len(nonWords))
+	i, j := 0, 0
+	for i < len(words) || j < len(nonWords) {
+		switch {
+		case j >= len(nonWords),
+			i < len(words) && words[i].Start < nonWords[j].Start:
+			list = append(list, words[i])
+			i++
+		default:
+			list = append(list, nonWords[j])
+			j++
+		}
+	}
+	t.allTokens = &tokenizer.TokenList{
+		List:            list,
+		UseOriginalForm: t.words.UseOriginalForm,
+	}
+	return t.allTokens
+}
+
+// GetRawSurface returns the text of the token position from raw text data.
+// This is valid only when the prefilters keep the rune offsets (e.g. prefilter.HTML, prefilter.Markdown).
+func (t *TextData) GetRawSurface(token *tokenizer.Token) string {
+	runes := []rune(t.raw)
+	if token.Start < 0 || token.End > len(runes) || token.Start > token.End {
+		return token.GetSurface()
+	}
+	return string(runes[token.Start:token.End])
+}
+
+// GetColumn returns the value of the column in the input row.
+// It returns empty string when the column does not exist.
+func (t *TextData) GetColumn(name string) string {
+	for i, col := range t.header {
+		if col == name && i < len(t.line) {
+			return t.line[i]
+		}
+	}
+	return ""
+}
+
+// GetSentences returns sentences of raw text data
+func (t *TextData) GetSentences() []string {
+	if t.sentences == nil {
+		t.sentences = tokenizer.SplitSentences(t.raw)
+	}
+	return t.sentences
+}
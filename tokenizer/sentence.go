@@ -0,0 +1,167 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitSentences separates text into sentences.
+//
+// the text is separated by '。', '！', '？', ellipses and newlines.
+// terminators inside of brackets (e.g. 「」, 『』) do not separate the sentence,
+// and closing brackets after terminators are included in the sentence.
+// an opening bracket without the closing bracket in the same line is treated as a normal letter.
+func SplitSentences(text string) []string {
+	runes := []rune(text)
+	size := len(runes)
+
+	var sentences []string
+	var b strings.Builder
+	flush := func() {
+		if s := strings.TrimSpace(b.String()); s != "" {
+			sentences = append(sentences, s)
+		}
+		b.Reset()
+	}
+
+	depth := 0
+	for i := 0; i < size; i++ {
+		c := runes[i]
+		switch {
+		case isNewline(c):
+			flush()
+			depth = 0
+			continue
+		case isOpenBracket(c):
+			if hasCloseBracket(runes, i) {
+				depth++
+			}
+		case isCloseBracket(c):
+			if depth > 0 {
+				depth--
+			}
+			_, _ = b.WriteRune(c)
+			// 「はい。」 is a sentence, but 「はい。」と言った is not.
+			if depth == 0 && i > 0 && isTerminator(runes[i-1]) && !isQuoteParticle(runes, i+1) {
+				flush()
+			}
+			continue
+		}
+
+		_, _ = b.WriteRune(c)
+		if depth > 0 {
+			continue
+		}
+
+		switch {
+		case isTerminator(c):
+			// include the rest of terminators and closing brackets.
+			for i+1 < size && (isTerminator(runes[i+1]) || isEllipsis(runes, i+1)) {
+				i++
+				_, _ = b.WriteRune(runes[i])
+			}
+			if i+1 < size && isCloseBracket(runes[i+1]) {
+				continue
+			}
+			flush()
+		case isEllipsis(runes, i):
+			for i+1 < size && (isEllipsis(runes, i+1) || isTerminator(runes[i+1])) {
+				i++
+				_, _ = b.WriteRune(runes[i])
+			}
+			// ellipsis is the end of sentence only when followed by space.
+			if i+1 >= size || unicode.IsSpace(runes[i+1]) {
+				flush()
+			}
+		}
+	}
+	flush()
+	return sentences
+}
+
+func isNewline(c rune) bool {
+	switch c {
+	case '\n', '\r', '\u2028', '\u2029':
+		return true
+	}
+	return false
+}
+
+func isTerminator(c rune) bool {
+	switch c {
+	case '。', '！', '？', '!', '?', '｡':
+		return true
+	}
+	return false
+}
+
+// isEllipsis checks the rune of the position is ellipsis ('…', '‥', '...') or not.
+func isEllipsis(runes []rune, pos int) bool {
+	switch runes[pos] {
+	case '…', '‥':
+		return true
+	case '.':
+		return (pos+1 < len(runes) && runes[pos+1] == '.') || (pos > 0 && runes[pos-1] == '.')
+	}
+	return false
+}
+
+func isOpenBracket(c rune) bool {
+	switch c {
+	case '「', '『', '（', '(', '【', '〈', '《', '｢':
+		return true
+	}
+	return false
+}
+
+func isCloseBracket(c rune) bool {
+	switch c {
+	case '」', '』', '）', ')', '】', '〉', '》', '｣':
+		return true
+	}
+	return false
+}
+
+// hasCloseBracket checks the closing bracket for the opening bracket of the position exists in the same line or not.
+func hasCloseBracket(runes []rune, pos int) bool {
+	opening := runes[pos]
+	closing := bracketPairs[opening]
+	depth := 0
+	for _, c := range runes[pos:] {
+		switch {
+		case isNewline(c):
+			return false
+		case c == opening:
+			depth++
+		case c == closing:
+			depth--
+			if depth == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var bracketPairs = map[rune]rune{
+	'「': '」',
+	'『': '』',
+	'（': '）',
+	'(': ')',
+	'【': '】',
+	'〈': '〉',
+	'《': '》',
+	'｢': '｣',
+}
+
+// isQuoteParticle checks the text from the position starts with quotation particle or not.
+func isQuoteParticle(runes []rune, pos int) bool {
+	if pos >= len(runes) {
+		return false
+	}
+	switch runes[pos] {
+	case 'と', 'っ':
+		return true
+	}
+	return false
+}
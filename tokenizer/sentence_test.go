@@ -0,0 +1,38 @@
+package tokenizer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"", nil},
+		{"今日は晴れ。明日は雨！本当？", []string{"今日は晴れ。", "明日は雨！", "本当？"}},
+		{"えっ！？まさか", []string{"えっ！？", "まさか"}},
+		// newlines separate the sentence
+		{"一行目\n二行目\r\n三行目", []string{"一行目", "二行目", "三行目"}},
+		// literal '\n' is not a newline
+		{`一行目\n二行目`, []string{`一行目\n二行目`}},
+		// terminators inside of brackets
+		{"彼は「はい。そうです。」と言った。次へ。", []string{"彼は「はい。そうです。」と言った。", "次へ。"}},
+		// closing bracket after terminator
+		{"「はい。」「いいえ。」", []string{"「はい。」", "「いいえ。」"}},
+		{"（笑）。次", []string{"（笑）。", "次"}},
+		// unmatched opening bracket is a normal letter
+		{"1) 「未完。次の文。", []string{"1) 「未完。", "次の文。"}},
+		{"「閉じない\n次の行。終わり。", []string{"「閉じない", "次の行。", "終わり。"}},
+		// ellipsis
+		{"そうか… 次へ", []string{"そうか…", "次へ"}},
+		{"待って...続き", []string{"待って...続き"}},
+	}
+
+	for _, tt := range tests {
+		if got := SplitSentences(tt.text); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("SplitSentences(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
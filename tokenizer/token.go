@@ -2,6 +2,7 @@ package tokenizer
 
 import (
 	"regexp"
+	"unicode"
 
 	"github.com/ikawaha/kagome/tokenizer"
 )
@@ -52,6 +53,20 @@ func (t *Token) GetOriginalForm() string {
 	}
 }
 
+// GetReading returns the reading (katakana) of surface text.
+// It returns empty string when the reading is unknown.
+func (t *Token) GetReading() string {
+	if len(t.features) < 8 {
+		return ""
+	}
+
+	s := t.features[7]
+	if s == "*" {
+		return ""
+	}
+	return s
+}
+
 // HasFeature checks token contains the feature or not.
 func (t *Token) HasFeature(f string) bool {
 	for _, val := range t.features {
@@ -62,6 +77,19 @@ func (t *Token) HasFeature(f string) bool {
 	return false
 }
 
+// IsNumber checks the token is number or not.
+func (t *Token) IsNumber() bool {
+	if t.HasFeature("数") {
+		return true
+	}
+	for _, s := range t.GetSurface() {
+		if !unicode.IsDigit(s) {
+			return false
+		}
+	}
+	return true
+}
+
 // TokenList is token slice list
 type TokenList struct {
 	List            []*Token
@@ -25,6 +25,9 @@ type Tokenizer struct {
 	wordPosMap      map[string]struct{}
 	stopWordMap     map[string]struct{}
 	useOriginalForm bool
+	keyFunc         func(string) string
+	useReadingKey   bool
+	numberMask      string
 }
 
 // New returns initialized Tokenizer.
@@ -34,6 +37,9 @@ func New(c Config) *Tokenizer {
 		wordPosList:     defaultWordPosList,
 		minLetterSize:   1,
 		useOriginalForm: c.UseOriginalForm,
+		keyFunc:         c.KeyFunc,
+		useReadingKey:   c.UseReadingKey,
+		numberMask:      c.NumberMask,
 	}
 
 	if c.MinLetterSize > 1 {
@@ -50,7 +56,7 @@ func New(c Config) *Tokenizer {
 
 	t.stopWordMap = make(map[string]struct{}, len(c.StopWordList))
 	for _, p := range c.StopWordList {
-		t.stopWordMap[p] = struct{}{}
+		t.stopWordMap[t.GetKey(p)] = struct{}{}
 	}
 
 	return t
@@ -70,10 +76,61 @@ func (t *Tokenizer) SetDictionary(path string) error {
 // AddStopWords adds word into stop word list.
 func (t *Tokenizer) AddStopWords(list ...string) {
 	for _, p := range list {
-		t.stopWordMap[p] = struct{}{}
+		t.stopWordMap[t.GetKey(p)] = struct{}{}
 	}
 }
 
+// GetKey returns the key of the word for matching and ranking.
+func (t *Tokenizer) GetKey(word string) string {
+	if t.keyFunc == nil {
+		return word
+	}
+	return t.keyFunc(word)
+}
+
+// GetTokenKeys returns the keys of the tokens for matching and ranking.
+func (t *Tokenizer) GetTokenKeys(list *TokenList) []string {
+	words := list.GetWords()
+	if t.useReadingKey {
+		for i, token := range list.List {
+			if r := token.GetReading(); r != "" {
+				words[i] = r
+			}
+		}
+	}
+	keys := t.GetKeys(words)
+	if t.numberMask == "" {
+		return keys
+	}
+	for i, token := range list.List {
+		if token.IsNumber() {
+			keys[i] = t.numberMask
+		}
+	}
+	return keys
+}
+
+// MaskNumber returns the placeholder for the number token.
+func (t *Tokenizer) MaskNumber(token *Token) (string, bool) {
+	if t.numberMask == "" || !token.IsNumber() {
+		return "", false
+	}
+	return t.numberMask, true
+}
+
+// GetKeys returns the keys of the words for matching and ranking.
+func (t *Tokenizer) GetKeys(words []string) []string {
+	if t.keyFunc == nil {
+		return words
+	}
+
+	keys := make([]string, len(words))
+	for i, w := range words {
+		keys[i] = t.keyFunc(w)
+	}
+	return keys
+}
+
 // Tokenize separates text into tokens(words) and return the list
 func (t *Tokenizer) Tokenize(text string) (*TokenList, *TokenList) {
 	tokens := t.t.Tokenize(text)
@@ -86,7 +143,7 @@ func (t *Tokenizer) Tokenize(text string) (*TokenList, *TokenList) {
 		}
 
 		nt := newToken(token)
-		if t.isValidWord(nt.GetPos(), nt.GetSurface()) {
+		if t.isValidWord(nt) {
 			words = append(words, nt)
 		} else {
 			nonWords = append(nonWords, nt)
@@ -104,14 +161,33 @@ func (t *Tokenizer) Tokenize(text string) (*TokenList, *TokenList) {
 	return wordList, nonList
 }
 
-func (t *Tokenizer) isValidWord(pos, surface string) bool {
+// TokenizeAll separates text into tokens and return all of the tokens in order.
+func (t *Tokenizer) TokenizeAll(text string) *TokenList {
+	tokens := t.t.Tokenize(text)
+
+	list := make([]*Token, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Class == tokenizer.DUMMY {
+			continue
+		}
+		list = append(list, newToken(token))
+	}
+	return &TokenList{
+		List:            list,
+		UseOriginalForm: t.useOriginalForm,
+	}
+}
+
+func (t *Tokenizer) isValidWord(token *Token) bool {
+	pos := token.GetPos()
+	surface := token.GetSurface()
 	if _, ok := t.wordPosMap[pos]; !ok {
 		return false
 	}
 	if len(surface) < t.minLetterSize {
 		return false
 	}
-	if _, ok := t.stopWordMap[surface]; ok {
+	if t.isStopWord(token) {
 		return false
 	}
 	// ignore a word which letters contains only special signs.
@@ -122,10 +198,33 @@ func (t *Tokenizer) isValidWord(pos, surface string) bool {
 	return true
 }
 
+// isStopWord checks the key of the surface (or the reading) is in the stop word list.
+func (t *Tokenizer) isStopWord(token *Token) bool {
+	if _, ok := t.stopWordMap[t.GetKey(token.GetSurface())]; ok {
+		return true
+	}
+	if !t.useReadingKey {
+		return false
+	}
+
+	r := token.GetReading()
+	if r == "" {
+		return false
+	}
+	_, ok := t.stopWordMap[t.GetKey(r)]
+	return ok
+}
+
 // Config for Tokenizer.
 type Config struct {
 	MinLetterSize   int
 	WordPosList     []string
 	StopWordList    []string
 	UseOriginalForm bool
+	// KeyFunc normalizes the word for matching stop words and ranking
+	KeyFunc func(string) string
+	// UseReadingKey uses the reading of the word as the key
+	UseReadingKey bool
+	// NumberMask is the placeholder to replace number words (e.g. '<NUM>')
+	NumberMask string
 }
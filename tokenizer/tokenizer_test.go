@@ -0,0 +1,27 @@
+package tokenizer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetTokenKeys(t *testing.T) {
+	tests := []struct {
+		text     string
+		conf     Config
+		expected []string
+	}{
+		{"3500円を払う", Config{}, []string{"3500", "円", "払う"}},
+		{"3500円を払う", Config{NumberMask: "<NUM>"}, []string{"<NUM>", "円", "払う"}},
+		// literal text of the mask is not a number
+		{"NUMの話", Config{NumberMask: "<NUM>"}, []string{"NUM", "話"}},
+	}
+
+	for _, tt := range tests {
+		tok := New(tt.conf)
+		words, _ := tok.Tokenize(tt.text)
+		if got := tok.GetTokenKeys(words); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("GetTokenKeys(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
@@ -57,7 +57,9 @@ func (w *Writer) Close() error {
 	if w.fp == nil {
 		return nil
 	}
-	return w.fp.Close()
+	err := w.fp.Close()
+	w.fp = nil
+	return err
 }
 
 // writer is interface of actual writes line into files
@@ -0,0 +1,143 @@
+package writer
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Graph is network data for visualization.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// GraphNode is a node of the graph.
+type GraphNode struct {
+	ID     string
+	Label  string
+	Weight int
+}
+
+// GraphEdge is an edge of the graph.
+type GraphEdge struct {
+	Source string
+	Target string
+	Weight float64
+	Count  int
+}
+
+// WriteGraphToFile writes the graph into file by its format (.graphml or .gexf)
+func WriteGraphToFile(filepath string, g Graph) error {
+	var fn func(io.Writer, Graph) error
+	switch ext := path.Ext(filepath); ext {
+	case ".graphml":
+		fn = writeGraphML
+	case ".gexf":
+		fn = writeGEXF
+	default:
+		return fmt.Errorf("non supported graph format: %s", ext)
+	}
+
+	fp, err := os.Create(filepath)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(fp)
+	if err := fn(w, g); err != nil {
+		_ = fp.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = fp.Close()
+		return err
+	}
+	return fp.Close()
+}
+
+func writeGraphML(w io.Writer, g Graph) error {
+	b := &xmlBuilder{w: w}
+	b.write(xml.Header)
+	b.write(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	b.write(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	b.write(`  <key id="count" for="node" attr.name="count" attr.type="int"/>` + "\n")
+	b.write(`  <key id="weight" for="edge" attr.name="weight" attr.type="double"/>` + "\n")
+	b.write(`  <key id="ecount" for="edge" attr.name="count" attr.type="int"/>` + "\n")
+	b.write(`  <graph id="G" edgedefault="undirected">` + "\n")
+	for _, n := range g.Nodes {
+		b.write(`    <node id="`, escape(n.ID), `">`)
+		b.write(`<data key="label">`, escape(n.Label), `</data>`)
+		b.write(`<data key="count">`, strconv.Itoa(n.Weight), `</data>`)
+		b.write("</node>\n")
+	}
+	for i, e := range g.Edges {
+		b.write(`    <edge id="e`, strconv.Itoa(i), `" source="`, escape(e.Source), `" target="`, escape(e.Target), `">`)
+		b.write(`<data key="weight">`, formatFloat(e.Weight), `</data>`)
+		b.write(`<data key="ecount">`, strconv.Itoa(e.Count), `</data>`)
+		b.write("</edge>\n")
+	}
+	b.write("  </graph>\n")
+	b.write("</graphml>\n")
+	return b.err
+}
+
+func writeGEXF(w io.Writer, g Graph) error {
+	b := &xmlBuilder{w: w}
+	b.write(xml.Header)
+	b.write(`<gexf xmlns="http://www.gexf.net/1.2draft" version="1.2">` + "\n")
+	b.write(`  <graph mode="static" defaultedgetype="undirected">` + "\n")
+	b.write(`    <attributes class="node">` + "\n")
+	b.write(`      <attribute id="0" title="count" type="integer"/>` + "\n")
+	b.write("    </attributes>\n")
+	b.write(`    <attributes class="edge">` + "\n")
+	b.write(`      <attribute id="0" title="count" type="integer"/>` + "\n")
+	b.write("    </attributes>\n")
+	b.write("    <nodes>\n")
+	for _, n := range g.Nodes {
+		b.write(`      <node id="`, escape(n.ID), `" label="`, escape(n.Label), `">`)
+		b.write(`<attvalues><attvalue for="0" value="`, strconv.Itoa(n.Weight), `"/></attvalues>`)
+		b.write("</node>\n")
+	}
+	b.write("    </nodes>\n")
+	b.write("    <edges>\n")
+	for i, e := range g.Edges {
+		b.write(`      <edge id="`, strconv.Itoa(i), `" source="`, escape(e.Source), `" target="`, escape(e.Target), `" weight="`, formatFloat(e.Weight), `">`)
+		b.write(`<attvalues><attvalue for="0" value="`, strconv.Itoa(e.Count), `"/></attvalues>`)
+		b.write("</edge>\n")
+	}
+	b.write("    </edges>\n")
+	b.write("  </graph>\n")
+	b.write("</gexf>\n")
+	return b.err
+}
+
+// xmlBuilder writes strings and keeps the first error.
+type xmlBuilder struct {
+	w   io.Writer
+	err error
+}
+
+func (b *xmlBuilder) write(list ...string) {
+	for _, s := range list {
+		if b.err != nil {
+			return
+		}
+		_, b.err = io.WriteString(b.w, s)
+	}
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 5, 64)
+}
@@ -0,0 +1,45 @@
+package writer
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// NewPlainFromFile returns initialized Writer for plain text file.
+// each line is written as space separated text.
+func NewPlainFromFile(filepath string) (*Writer, error) {
+	fp, err := os.Create(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		fp: fp,
+		w:  newPlainWriter(fp),
+	}, nil
+}
+
+func newPlainWriter(fp *os.File) writer {
+	return &plainWriter{
+		w: bufio.NewWriter(fp),
+	}
+}
+
+// plainWriter writes a line as space separated text.
+type plainWriter struct {
+	w *bufio.Writer
+}
+
+// Write writes a line.
+func (w *plainWriter) Write(line []string) error {
+	if _, err := w.w.WriteString(strings.Join(line, " ")); err != nil {
+		return err
+	}
+	return w.w.WriteByte('\n')
+}
+
+// Flush writes buffered data into file.
+func (w *plainWriter) Flush() {
+	_ = w.w.Flush()
+}
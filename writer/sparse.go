@@ -0,0 +1,141 @@
+package writer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+)
+
+// sparse matrix formats.
+const (
+	FormatMatrixMarket = "mm"
+	FormatLIBSVM       = "libsvm"
+)
+
+// SparseValue is a non-zero value of the sparse row.
+type SparseValue struct {
+	Index int // first=0
+	Value float64
+}
+
+// SparseWriter writes sparse matrix into file.
+type SparseWriter struct {
+	fp     *os.File
+	w      *bufio.Writer
+	format string
+
+	// for Matrix Market, body is written into temporary file
+	// because the header needs the size of matrix.
+	tmp     *os.File
+	tmpW    *bufio.Writer
+	rows    int
+	cols    int
+	nonZero int
+}
+
+// NewSparseFromFile returns initialized SparseWriter for file.
+func NewSparseFromFile(filepath, format string, cols int) (*SparseWriter, error) {
+	switch format {
+	case FormatMatrixMarket, FormatLIBSVM:
+	default:
+		return nil, fmt.Errorf("non supported sparse format: %s", format)
+	}
+
+	fp, err := os.Create(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &SparseWriter{
+		fp:     fp,
+		w:      bufio.NewWriter(fp),
+		format: format,
+		cols:   cols,
+	}
+	if format == FormatMatrixMarket {
+		w.tmp, err = ioutil.TempFile("", "go-jp-text-ripper-mm-")
+		if err != nil {
+			_ = fp.Close()
+			return nil, err
+		}
+		w.tmpW = bufio.NewWriter(w.tmp)
+	}
+	return w, nil
+}
+
+// WriteRow writes a row of the matrix. label is used for LIBSVM format.
+func (w *SparseWriter) WriteRow(label string, row []SparseValue) error {
+	w.rows++
+	w.nonZero += len(row)
+	switch w.format {
+	case FormatLIBSVM:
+		return w.writeLIBSVM(label, row)
+	default:
+		return w.writeMatrixMarket(row)
+	}
+}
+
+func (w *SparseWriter) writeLIBSVM(label string, row []SparseValue) error {
+	if label == "" {
+		label = "0"
+	}
+	if _, err := w.w.WriteString(label); err != nil {
+		return err
+	}
+	for _, v := range row {
+		if _, err := fmt.Fprintf(w.w, " %d:%s", v.Index+1, formatSparseValue(v.Value)); err != nil {
+			return err
+		}
+	}
+	return w.w.WriteByte('\n')
+}
+
+func (w *SparseWriter) writeMatrixMarket(row []SparseValue) error {
+	for _, v := range row {
+		if _, err := fmt.Fprintf(w.tmpW, "%d %d %s\n", w.rows, v.Index+1, formatSparseValue(v.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the rest of data and closes file.
+func (w *SparseWriter) Close() error {
+	if w.format == FormatMatrixMarket {
+		if err := w.flushMatrixMarket(); err != nil {
+			_ = w.fp.Close()
+			return err
+		}
+	}
+	if err := w.w.Flush(); err != nil {
+		_ = w.fp.Close()
+		return err
+	}
+	return w.fp.Close()
+}
+
+func (w *SparseWriter) flushMatrixMarket() error {
+	defer func() {
+		_ = w.tmp.Close()
+		_ = os.Remove(w.tmp.Name())
+	}()
+
+	if err := w.tmpW.Flush(); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w.w, "%%%%MatrixMarket matrix coordinate real general\n%d %d %d\n", w.rows, w.cols, w.nonZero); err != nil {
+		return err
+	}
+	if _, err := w.tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := io.Copy(w.w, w.tmp)
+	return err
+}
+
+func formatSparseValue(f float64) string {
+	return strconv.FormatFloat(f, 'g', 8, 64)
+}